@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/borgenk/qdo/core"
@@ -14,6 +15,7 @@ import (
 	"github.com/borgenk/qdo/log/syslog"
 	"github.com/borgenk/qdo/store"
 	_ "github.com/borgenk/qdo/store/leveldb"
+	"github.com/borgenk/qdo/worker"
 )
 
 const Version = "0.3.0"
@@ -27,6 +29,12 @@ func main() {
 	optHTTPPort := flag.Int("p", defaultOptHTTPPort, "HTTP port")
 	optDBFilepath := flag.String("f", defaultOptDBFilepath, "Database filepath")
 	optSyslog := flag.Bool("s", defaultOptSyslog, "Log to syslog")
+	optTargetScheme := flag.String("target-scheme", "", "Comma separated URL schemes targets may use (default http,https)")
+	optTargetAllow := flag.String("target-allow", "", "Comma separated hosts, *.domains, addresses or CIDR ranges targets must match")
+	optTargetDeny := flag.String("target-deny", "", "Comma separated hosts, *.domains, addresses or CIDR ranges targets must not match, private for internal addresses")
+	optMaxPayload := flag.Int("max-payload", 0, "Maximum task payload size in bytes, 0 for no limit")
+	var optCommands listFlag
+	flag.Var(&optCommands, "command", "Command exec:// targets may run, as \"name /path/to/program args\", repeat for more")
 	flag.Parse()
 
 	// Setup logging method.
@@ -42,6 +50,25 @@ func main() {
 	}
 	log.Infof("starting QDo %s", Version)
 
+	// Target policy of all queues.
+	err := worker.SetTargetPolicy(&worker.TargetPolicy{
+		Schemes:    splitFlag(*optTargetScheme),
+		Allow:      splitFlag(*optTargetAllow),
+		Deny:       splitFlag(*optTargetDeny),
+		MaxPayload: int32(*optMaxPayload),
+	})
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		os.Exit(2)
+	}
+
+	// Programs exec:// targets may run.
+	err = worker.SetCommands(optCommands)
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		os.Exit(2)
+	}
+
 	// Launch web admin interface server.
 	go http.Run(*optHTTPPort)
 
@@ -61,3 +88,27 @@ func main() {
 	log.Info("stopping..")
 	manager.Stop()
 }
+
+// listFlag collects the values of a flag given more than once.
+type listFlag []string
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *listFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// splitFlag splits a comma separated flag value, dropping empty items.
+func splitFlag(value string) []string {
+	var res []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
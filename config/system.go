@@ -10,4 +10,12 @@ const (
 	QueueKey         string = "q"
 	WaitQueueKey     string = "w"
 	ScheduleQueueKey string = "s"
+	DeadQueueKey     string = "d"
+	HistoryKey       string = "a"
+	IdempotencyKey   string = "i"
+	CronKey          string = "c"
+	PipelineKey      string = "p"
+	BatchKey         string = "b"
+	HostLimitKey     string = "h"
+	TaskIndexKey     string = "t"
 )
@@ -35,7 +35,12 @@ func StartController(db store.Store) (*Controller, error) {
 		wg:     &sync.WaitGroup{},
 		db:     db,
 	}
-	err := controller.start()
+	worker.SetQueueLookup(GetQueue)
+	err := worker.LoadHostLimits(db)
+	if err != nil {
+		return nil, err
+	}
+	err = controller.start()
 	if err != nil {
 		return nil, err
 	}
@@ -47,9 +52,9 @@ func (c *Controller) start() error {
 	if err != nil {
 		return err
 	}
-	for _, v := range *storedQueues {
-		c.queues[v.ID] = &v
-		go c.queues[v.ID].Initialize(c.db, c.wg).Start()
+	for _, q := range storedQueues {
+		c.queues[q.ID] = q
+		go q.Initialize(c.db, c.wg).Start()
 		c.wg.Add(1)
 	}
 	return nil
@@ -95,23 +100,63 @@ func GetAllQueues() ([]*worker.QueueManager, error) {
 	return res, nil
 }
 
+// GetPipeline returns the progress of a pipeline.
+func GetPipeline(pipelineID string) (*worker.Pipeline, error) {
+	if controller == nil {
+		return nil, ErrControllerNotInit
+	}
+	return worker.GetPipeline(controller.db, pipelineID)
+}
+
+// SetHostLimit adds or changes a limit on a host shared by all queues.
+func SetHostLimit(limit *worker.HostLimit) error {
+	if controller == nil {
+		return ErrControllerNotInit
+	}
+	return worker.SetHostLimit(controller.db, limit)
+}
+
+// GetHostLimit returns the limit on a host and its current usage.
+func GetHostLimit(host string) (*worker.HostLimitState, error) {
+	if controller == nil {
+		return nil, ErrControllerNotInit
+	}
+	return worker.GetHostLimit(host)
+}
+
+// GetHostLimits returns all host limits and their current usage.
+func GetHostLimits() ([]worker.HostLimitState, error) {
+	if controller == nil {
+		return nil, ErrControllerNotInit
+	}
+	return worker.GetHostLimits(), nil
+}
+
+// DeleteHostLimit removes the limit on a host.
+func DeleteHostLimit(host string) error {
+	if controller == nil {
+		return ErrControllerNotInit
+	}
+	return worker.DeleteHostLimit(controller.db, host)
+}
+
 // getAllStoredQueues retrieves all stored queue managers.
-func getAllStoredQueues() (*[]worker.QueueManager, error) {
-	res := []worker.QueueManager{}
+func getAllStoredQueues() ([]*worker.QueueManager, error) {
+	res := []*worker.QueueManager{}
 	iter := controller.db.NewIterator(nil)
 	defer iter.Close()
 	for iter.Seek([]byte(config.QueueManagerKey + config.Prefix)); iter.Valid(); iter.Next() {
 		if bytes.Compare(iter.Key(), []byte(config.QueueManagerKey+config.Suffix)) > 0 {
 			break
 		}
-		c := worker.QueueManager{}
-		err := GobDecode(iter.Value(), &c)
+		c := &worker.QueueManager{}
+		err := GobDecode(iter.Value(), c)
 		if err != nil {
 			panic("for now")
 		}
 		res = append(res, c)
 	}
-	return &res, nil
+	return res, nil
 }
 
 // AddQueue adds a new queue controller and starts it automatically. QueueID must
@@ -128,16 +173,15 @@ func AddQueue(queueID string, config *worker.Config) error {
 	if ok {
 		return ErrQueueAlreadyExist
 	}
+	err := config.Validate()
+	if err != nil {
+		return err
+	}
 
 	queue := worker.NewQueue(queueID, config, controller.db, controller.wg)
 	controller.queues[queueID] = queue
 
-	b, err := GobEncode(queue)
-	if err != nil {
-		log.Error("", err)
-		return err
-	}
-	err = controller.db.Put(getQueueManagerKey(queueID), b)
+	err = saveQueue(queue)
 	if err != nil {
 		log.Error("creating new queue failed", err)
 		return err
@@ -177,6 +221,79 @@ func RemoveQueue(queueID string) error {
 	return nil
 }
 
+// UpdateQueue switches a running queue to a new configuration and stores it.
+func UpdateQueue(queueID string, config *worker.Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if controller == nil {
+		return ErrControllerNotInit
+	}
+	queue, ok := controller.queues[queueID]
+	if !ok {
+		return ErrQueueNotFound
+	}
+	err := queue.Reconfigure(config)
+	if err != nil {
+		return err
+	}
+	err = saveQueue(queue)
+	if err != nil {
+		log.Error("updating queue failed", err)
+		return err
+	}
+	return nil
+}
+
+// PauseQueue stops the queue from dispatching tasks. The paused state is
+// stored and survives a restart.
+func PauseQueue(queueID string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if controller == nil {
+		return ErrControllerNotInit
+	}
+	queue, ok := controller.queues[queueID]
+	if !ok {
+		return ErrQueueNotFound
+	}
+	if queue.IsPaused() {
+		return nil
+	}
+	queue.Pause()
+	return saveQueue(queue)
+}
+
+// ResumeQueue continues dispatching tasks from a paused queue and ends a hold
+// asked for by a target, also on a queue that is not paused.
+func ResumeQueue(queueID string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if controller == nil {
+		return ErrControllerNotInit
+	}
+	queue, ok := controller.queues[queueID]
+	if !ok {
+		return ErrQueueNotFound
+	}
+	if !queue.Resume() {
+		return nil
+	}
+	return saveQueue(queue)
+}
+
+// saveQueue stores the queue manager so it can be restored on startup.
+func saveQueue(queue *worker.QueueManager) error {
+	b, err := GobEncode(queue)
+	if err != nil {
+		log.Error("", err)
+		return err
+	}
+	return controller.db.Put(getQueueManagerKey(queue.ID), b)
+}
+
 // getQueueManagerKey builds the queue controller key prefix.
 func getQueueManagerKey(queueID string) []byte {
 	return []byte(config.QueueManagerKey + config.Prefix + queueID)
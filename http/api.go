@@ -1,11 +1,18 @@
 package http
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	stdhttp "net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/borgenk/qdo/third_party/github.com/gorilla/mux"
 
@@ -19,11 +26,46 @@ func init() {
 	r.HandleFunc("/api/queue", getAllQueues).Methods("GET")
 	r.HandleFunc("/api/queue", createQueue).Methods("POST")
 	r.HandleFunc("/api/queue/{queue_id}", getQueue).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}", updateQueue).Methods("PATCH")
 	r.HandleFunc("/api/queue/{queue_id}", deleteQueue).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/pause", pauseQueue).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/resume", resumeQueue).Methods("POST")
 	r.HandleFunc("/api/queue/{queue_id}/task", getAllTasks).Methods("GET")
 	r.HandleFunc("/api/queue/{queue_id}/task", CreateTask).Methods("POST")
 	r.HandleFunc("/api/queue/{queue_id}/task", deleteAllTasks).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/task/bulk", createTasks).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}", getTask).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}", updateTask).Methods("PATCH")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}", cancelTask).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}/run", runTask).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}/defer", deferTask).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/scheduled/run", runScheduledTasks).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/lease", leaseTasks).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/cron", getAllCronJobs).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}/cron", createCronJob).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/cron/{cron_id}", getCronJob).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}/cron/{cron_id}", updateCronJob).Methods("PUT")
+	r.HandleFunc("/api/queue/{queue_id}/cron/{cron_id}", deleteCronJob).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/batch", getAllBatches).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}/batch", createBatch).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/batch/{batch_id}", getBatch).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}/batch/{batch_id}", deleteBatch).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/batch/{batch_id}/close", closeBatch).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}/ack", ackTask).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}/nack", nackTask).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/task/{task_id}/extend", extendLease).Methods("POST")
 	r.HandleFunc("/api/queue/{queue_id}/stats", getStats).Methods("GET")
+	r.HandleFunc("/api/pipeline/{pipeline_id}", getPipeline).Methods("GET")
+	r.HandleFunc("/api/host", getAllHostLimits).Methods("GET")
+	r.HandleFunc("/api/host", setHostLimit).Methods("POST")
+	r.HandleFunc("/api/host/{host:.+}", getHostLimit).Methods("GET")
+	r.HandleFunc("/api/host/{host:.+}", deleteHostLimit).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/dead", getAllDeadTasks).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}/dead", purgeDeadTasks).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/dead/requeue", requeueAllDeadTasks).Methods("POST")
+	r.HandleFunc("/api/queue/{queue_id}/dead/{task_id}", getDeadTask).Methods("GET")
+	r.HandleFunc("/api/queue/{queue_id}/dead/{task_id}", deleteDeadTask).Methods("DELETE")
+	r.HandleFunc("/api/queue/{queue_id}/dead/{task_id}/requeue", requeueDeadTask).Methods("POST")
 }
 
 type jsonListResult struct {
@@ -76,10 +118,6 @@ func getAllQueues(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 
 // API handler for POST /api/queue.
 func createQueue(w stdhttp.ResponseWriter, r *stdhttp.Request) {
-	var (
-		v   int
-		err error
-	)
 	reg, err := regexp.Compile("[^A-Za-z0-9]+")
 	if err != nil {
 		stdhttp.Error(w, "", stdhttp.StatusBadRequest)
@@ -92,41 +130,296 @@ func createQueue(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 		return
 	}
 	config := &worker.Config{}
-
-	v, err = strconv.Atoi(r.FormValue("max_concurrent"))
+	err = parseQueueConfig(r, config, true)
 	if err != nil {
 		log.Error("", err)
-		stdhttp.Error(w, "", stdhttp.StatusBadRequest)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
 		return
 	}
-	config.MaxConcurrent = int32(v)
-	v, err = strconv.Atoi(r.FormValue("max_rate"))
+	err = core.AddQueue(queueID, config)
 	if err != nil {
 		log.Error("", err)
-		stdhttp.Error(w, "", stdhttp.StatusBadRequest)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
 		return
 	}
-	config.MaxRate = int32(v)
-	v, err = strconv.Atoi(r.FormValue("task_timeout"))
+}
+
+// API handler for PATCH /api/queue/{queue_id}. Only the given fields are
+// changed, the rest of the configuration is kept.
+func updateQueue(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+	q, err := core.GetQueue(queueID)
 	if err != nil {
-		log.Error("", err)
-		stdhttp.Error(w, "", stdhttp.StatusBadRequest)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
 		return
 	}
-	config.TaskTimeout = int32(v)
-	v, err = strconv.Atoi(r.FormValue("task_max_tries"))
+	err = r.ParseForm()
 	if err != nil {
-		log.Error("", err)
-		stdhttp.Error(w, "", stdhttp.StatusBadRequest)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+		return
+	}
+	config := q.ConfigCopy()
+	err = parseQueueConfig(r, config, false)
+	if err == nil {
+		err = core.UpdateQueue(queueID, config)
+	}
+	if err == core.ErrQueueNotFound {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
 		return
 	}
-	config.TaskMaxTries = int32(v)
-	err = core.AddQueue(queueID, config)
 	if err != nil {
 		log.Error("", err)
-		stdhttp.Error(w, "", stdhttp.StatusBadRequest)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
 		return
 	}
+	ReturnJSON(w, r, q)
+}
+
+// parseQueueConfig reads the queue settings from the form values into
+// config. With required set max_concurrent, max_rate, task_timeout and
+// task_max_tries must be given, otherwise absent fields are left as they are.
+// max_rate is a count per second or a count per period such as 10/m. Giving
+// signing_secret replaces all secrets, an empty value turns signing off.
+func parseQueueConfig(r *stdhttp.Request, config *worker.Config, required bool) error {
+	ints := []struct {
+		name  string
+		value *int32
+		need  bool
+	}{
+		{"max_concurrent", &config.MaxConcurrent, required},
+		{"task_timeout", &config.TaskTimeout, required},
+		{"task_max_tries", &config.TaskMaxTries, required},
+		{"idempotency_window", &config.IdempotencyWindow, false},
+		{"history_retention", &config.HistoryRetention, false},
+		{"rate_burst", &config.RateBurst, false},
+		{"breaker_threshold", &config.BreakerThreshold, false},
+		{"breaker_cooldown", &config.BreakerCooldown, false},
+		{"max_pending", &config.MaxPending, false},
+	}
+	for _, f := range ints {
+		if r.FormValue(f.name) == "" && !f.need {
+			continue
+		}
+		v, err := strconv.Atoi(r.FormValue(f.name))
+		if err != nil {
+			return fmt.Errorf("invalid %s", f.name)
+		}
+		*f.value = int32(v)
+	}
+	if r.FormValue("max_rate") != "" || required {
+		err := parseRate(r.FormValue("max_rate"), config)
+		if err != nil {
+			return err
+		}
+	}
+	if r.FormValue("max_pending_bytes") != "" {
+		v, err := strconv.ParseInt(r.FormValue("max_pending_bytes"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid max_pending_bytes")
+		}
+		config.MaxPendingBytes = v
+	}
+	if _, ok := r.Form["overflow"]; ok {
+		config.Overflow = r.FormValue("overflow")
+	}
+	if r.FormValue("retry_after_hold") != "" {
+		v, err := strconv.ParseBool(r.FormValue("retry_after_hold"))
+		if err != nil {
+			return fmt.Errorf("invalid retry_after_hold")
+		}
+		config.RetryAfterHold = v
+	}
+	if _, ok := r.Form["mode"]; ok {
+		config.Mode = r.FormValue("mode")
+	}
+	if secrets, ok := r.Form["signing_secret"]; ok {
+		config.SigningSecrets = nil
+		for _, secret := range secrets {
+			if secret != "" {
+				config.SigningSecrets = append(config.SigningSecrets, secret)
+			}
+		}
+	}
+	err := parseRetryPolicy(r, &config.Retry)
+	if err != nil {
+		return err
+	}
+	err = parseTargetPolicy(r, &config.Target)
+	if err != nil {
+		return err
+	}
+	err = parseCommands(r, config)
+	if err != nil {
+		return err
+	}
+	err = parseExecutor(r, config)
+	if err != nil {
+		return err
+	}
+	return config.Validate()
+}
+
+// parseExecutor reads the executor and its options, given as key=value per
+// executor_option value or line. Given options replace all earlier ones, an
+// empty value removes them.
+func parseExecutor(r *stdhttp.Request, config *worker.Config) error {
+	if _, ok := r.Form["executor"]; ok {
+		config.Executor = r.FormValue("executor")
+	}
+	if values, ok := r.Form["executor_option"]; ok {
+		options := map[string]string{}
+		for _, v := range values {
+			for _, line := range strings.Split(v, "\n") {
+				line = strings.TrimSpace(line)
+				if line == "" {
+					continue
+				}
+				i := strings.Index(line, "=")
+				if i < 1 {
+					return fmt.Errorf("invalid executor_option %q, give key=value", line)
+				}
+				options[line[:i]] = line[i+1:]
+			}
+		}
+		config.ExecutorOptions = options
+	}
+	return nil
+}
+
+// parseCommands reads the commands the queue may run and the exit codes of
+// commands not retried. Commands themselves are only configured when starting
+// the daemon, the queue picks from them by name.
+func parseCommands(r *stdhttp.Request, config *worker.Config) error {
+	if values, ok := r.Form["command"]; ok {
+		config.Commands = splitList(values)
+	}
+	if values, ok := r.Form["exit_no_retry"]; ok {
+		config.ExitNoRetry = nil
+		for _, v := range splitList(values) {
+			code, err := strconv.Atoi(v)
+			if err != nil {
+				return worker.ErrConfigInvalidExit
+			}
+			config.ExitNoRetry = append(config.ExitNoRetry, int32(code))
+		}
+	}
+	return nil
+}
+
+// parseTargetPolicy reads the optional target_* and max_payload form values
+// into policy. Lists are given as repeated or comma separated values, an
+// empty value clears the list.
+func parseTargetPolicy(r *stdhttp.Request, policy *worker.TargetPolicy) error {
+	lists := []struct {
+		name  string
+		value *[]string
+	}{
+		{"target_scheme", &policy.Schemes},
+		{"target_allow", &policy.Allow},
+		{"target_deny", &policy.Deny},
+	}
+	for _, f := range lists {
+		if values, ok := r.Form[f.name]; ok {
+			*f.value = splitList(values)
+		}
+	}
+	if r.FormValue("max_payload") != "" {
+		v, err := strconv.Atoi(r.FormValue("max_payload"))
+		if err != nil {
+			return worker.ErrTargetInvalidPayload
+		}
+		policy.MaxPayload = int32(v)
+	}
+	return nil
+}
+
+// splitList splits comma separated form values and drops empty items.
+func splitList(values []string) []string {
+	var res []string
+	for _, v := range values {
+		for _, item := range strings.Split(v, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				res = append(res, item)
+			}
+		}
+	}
+	return res
+}
+
+// ratePeriods maps the unit of a max_rate value to its period in seconds.
+var ratePeriods = map[string]int32{
+	"s": 1,
+	"m": 60,
+	"h": 60 * 60,
+}
+
+// formatRate is the inverse of parseRate.
+func formatRate(maxRate, period int32) string {
+	for unit, p := range ratePeriods {
+		if p == period || (period == 0 && p == 1) {
+			return fmt.Sprintf("%d/%s", maxRate, unit)
+		}
+	}
+	return fmt.Sprintf("%d", maxRate)
+}
+
+// parseRate reads a rate such as 100, 100/s, 10/m or 5/h into config.
+func parseRate(value string, config *worker.Config) error {
+	period := int32(1)
+	if i := strings.Index(value, "/"); i >= 0 {
+		p, ok := ratePeriods[value[i+1:]]
+		if !ok {
+			return worker.ErrConfigInvalidRate
+		}
+		period = p
+		value = value[:i]
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return worker.ErrConfigInvalidRate
+	}
+	config.MaxRate = int32(v)
+	config.RatePeriod = period
+	return nil
+}
+
+// parseRetryPolicy reads the optional retry_* form values into policy.
+func parseRetryPolicy(r *stdhttp.Request, policy *worker.RetryPolicy) error {
+	var (
+		v   int
+		f   float64
+		err error
+	)
+	if _, ok := r.Form["retry_backoff"]; ok {
+		policy.Backoff = r.FormValue("retry_backoff")
+	}
+	if _, ok := r.Form["retry_jitter"]; ok {
+		policy.Jitter = r.FormValue("retry_jitter")
+	}
+	if r.FormValue("retry_initial_delay") != "" {
+		v, err = strconv.Atoi(r.FormValue("retry_initial_delay"))
+		if err != nil {
+			return worker.ErrRetryInvalidDelay
+		}
+		policy.InitialDelay = int32(v)
+	}
+	if r.FormValue("retry_max_delay") != "" {
+		v, err = strconv.Atoi(r.FormValue("retry_max_delay"))
+		if err != nil {
+			return worker.ErrRetryInvalidDelay
+		}
+		policy.MaxDelay = int32(v)
+	}
+	if r.FormValue("retry_multiplier") != "" {
+		f, err = strconv.ParseFloat(r.FormValue("retry_multiplier"), 64)
+		if err != nil {
+			return worker.ErrRetryInvalidMultiplier
+		}
+		policy.Multiplier = f
+	}
+	return policy.Validate()
 }
 
 // API handler for GET /api/queue/{queue_id}.
@@ -157,6 +450,32 @@ func deleteQueue(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	ReturnJSON(w, r, nil)
 }
 
+// API handler for POST /api/queue/{queue_id}/pause.
+func pauseQueue(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	setQueuePaused(w, r, core.PauseQueue)
+}
+
+// API handler for POST /api/queue/{queue_id}/resume.
+func resumeQueue(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	setQueuePaused(w, r, core.ResumeQueue)
+}
+
+func setQueuePaused(w stdhttp.ResponseWriter, r *stdhttp.Request, fn func(string) error) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+	err := fn(queueID)
+	if err == core.ErrQueueNotFound {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	res, _ := core.GetQueue(queueID)
+	ReturnJSON(w, r, res)
+}
+
 // API handler for GET /api/queue/{queue_id}/task
 func getAllTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	vars := mux.Vars(r)
@@ -185,7 +504,7 @@ func CreateTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 
 	q, err := core.GetQueue(queueID)
 	if err != nil {
-		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
 		return
 	}
 	scheduled := 0
@@ -196,75 +515,1225 @@ func CreateTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 			return
 		}
 	}
-	res, err := q.AddTask(r.FormValue("target"), r.FormValue("payload"),
-		int64(scheduled))
+	task, ttl, err := parseTask(r)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+		return
+	}
+	if ttl > 0 {
+		task.ExpiresAt = time.Now().Unix() + int64(ttl)
+	}
+	// A client supplied task id doubles as idempotency key unless one is given.
+	idempotencyKey := r.FormValue("idempotency_key")
+	if idempotencyKey == "" {
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+	}
+	batchID := r.FormValue("batch_id")
+	if batchID != "" && (idempotencyKey != "" || len(r.Form["stage"]) > 0) {
+		stdhttp.Error(w, "idempotency keys and stages can not be given for batch tasks", stdhttp.StatusBadRequest)
+		return
+	}
+	if idempotencyKey == "" {
+		idempotencyKey = task.ID
+	}
+	var (
+		res      *worker.Task
+		replayed bool
+	)
+	if batchID != "" {
+		// A task id is only taken once within a batch.
+		res, err = q.EnqueueBatch(task, int64(scheduled), batchID)
+	} else if values := r.Form["stage"]; len(values) > 0 {
+		// Further stages make the task the first stage of a pipeline.
+		if idempotencyKey != "" {
+			stdhttp.Error(w, "task_id and idempotency keys can not be given for pipelines", stdhttp.StatusBadRequest)
+			return
+		}
+		stages := make([]worker.PipelineStage, len(values))
+		for i, v := range values {
+			stages[i] = worker.ParsePipelineStage(v)
+		}
+		res, err = q.EnqueuePipeline(task, stages, int64(scheduled))
+	} else if idempotencyKey != "" {
+		res, replayed, err = q.EnqueueIdempotent(task, int64(scheduled), idempotencyKey)
+	} else {
+		res, err = q.Enqueue(task, int64(scheduled))
+	}
+	if err == worker.ErrQueueFull {
+		queueFullError(w, q)
+		return
+	}
 	if err != nil {
-		stdhttp.Error(w, "could not add task to database", stdhttp.StatusInternalServerError)
+		status := enqueueErrorStatus(err)
+		if status == stdhttp.StatusInternalServerError {
+			stdhttp.Error(w, "could not add task to database", status)
+			return
+		}
+		stdhttp.Error(w, err.Error(), status)
 		return
 	}
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
 	ReturnJSON(w, r, res)
 }
 
-// API handler for DELETE /api/queue/{queue_id}/task.
-func deleteAllTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+// enqueueErrorStatus returns the status answered for a task that could not be
+// added.
+func enqueueErrorStatus(err error) int {
+	switch err {
+	case worker.ErrTaskInvalidMethod, worker.ErrTaskInvalidHeader, worker.ErrTaskInvalidID,
+		worker.ErrTaskInvalidKey, worker.ErrTaskInvalidPriority,
+		worker.ErrPipelineInvalidStages, worker.ErrPipelineInvalidQueue, worker.ErrTaskInvalidTarget,
+		worker.ErrTargetScheme, worker.ErrTargetHostDenied, worker.ErrTargetPayloadSize,
+		worker.ErrCommandNotFound:
+		return stdhttp.StatusBadRequest
+	case worker.ErrBatchNotFound:
+		return stdhttp.StatusNotFound
+	case worker.ErrBatchNotOpen, worker.ErrBatchDuplicateTask, worker.ErrTaskAlreadyExist:
+		return stdhttp.StatusConflict
+	case worker.ErrQueueFull:
+		return stdhttp.StatusTooManyRequests
+	}
+	return stdhttp.StatusInternalServerError
+}
+
+// queueFullError writes the response for a task turned away by a full queue,
+// telling the client when to try again.
+func queueFullError(w stdhttp.ResponseWriter, q *worker.QueueManager) {
+	setFullRetryAfter(w, q)
+	stdhttp.Error(w, worker.ErrQueueFull.Error(), stdhttp.StatusTooManyRequests)
+}
+
+func setFullRetryAfter(w stdhttp.ResponseWriter, q *worker.QueueManager) {
+	retryAfter := (q.FullRetryAfter() + time.Second - 1) / time.Second
+	w.Header().Set("Retry-After", strconv.FormatInt(int64(retryAfter), 10))
+}
+
+// parseTask reads a task from the form values. A ttl in seconds is returned
+// apart, for the caller to count from the time the task is added.
+func parseTask(r *stdhttp.Request) (*worker.Task, int32, error) {
+	var ttl int32
+	task := &worker.Task{
+		ID:          r.FormValue("task_id"),
+		Target:      r.FormValue("target"),
+		Method:      r.FormValue("method"),
+		ContentType: r.FormValue("content_type"),
+		Payload:     r.FormValue("payload"),
+	}
+	if r.FormValue("priority") != "" {
+		priority, err := strconv.Atoi(r.FormValue("priority"))
+		if err != nil {
+			return nil, 0, errors.New("value for priority is invalid")
+		}
+		task.Priority = int32(priority)
+	}
+	if r.FormValue("expires_at") != "" && r.FormValue("ttl") != "" {
+		return nil, 0, errors.New("only one of expires_at and ttl can be given")
+	}
+	if r.FormValue("expires_at") != "" {
+		expiresAt, err := strconv.ParseInt(r.FormValue("expires_at"), 10, 64)
+		if err != nil || expiresAt <= 0 {
+			return nil, 0, errors.New("value for expires_at is invalid")
+		}
+		task.ExpiresAt = expiresAt
+	}
+	if r.FormValue("ttl") != "" {
+		v, err := strconv.Atoi(r.FormValue("ttl"))
+		if err != nil || v <= 0 {
+			return nil, 0, errors.New("value for ttl is invalid")
+		}
+		ttl = int32(v)
+	}
+	if r.FormValue("payload_encoding") == "base64" {
+		b, err := base64.StdEncoding.DecodeString(task.Payload)
+		if err != nil {
+			return nil, 0, errors.New("value for payload is not valid base64")
+		}
+		task.Payload = string(b)
+	} else if r.FormValue("payload_encoding") != "" {
+		return nil, 0, errors.New("value for payload_encoding is invalid")
+	}
+	// Headers are given as repeated "header" values on the form "Name: value".
+	for _, h := range r.Form["header"] {
+		i := strings.Index(h, ":")
+		if i < 1 {
+			return nil, 0, errors.New("value for header is invalid")
+		}
+		if task.Headers == nil {
+			task.Headers = make(map[string]string)
+		}
+		task.Headers[strings.TrimSpace(h[:i])] = strings.TrimSpace(h[i+1:])
+	}
+	return task, ttl, nil
+}
+
+const (
+	// DefaultMaxBulkBytes is the size a bulk enqueue body may have, unless a
+	// single task allowed by the global payload limit needs more.
+	DefaultMaxBulkBytes = 32 << 20
+	// bulkTaskOverhead is the room for the fields of a task besides its
+	// payload.
+	bulkTaskOverhead = 64 << 10
+)
+
+// bulkTask is a task of a bulk enqueue, with the fields of the CreateTask
+// form. A payload given as JSON other than a string is taken as is.
+type bulkTask struct {
+	ID              string            `json:"task_id"`
+	Target          string            `json:"target"`
+	Method          string            `json:"method"`
+	Headers         map[string]string `json:"headers"`
+	ContentType     string            `json:"content_type"`
+	Payload         json.RawMessage   `json:"payload"`
+	PayloadEncoding string            `json:"payload_encoding"`
+	Priority        int32             `json:"priority"`
+	Scheduled       int64             `json:"scheduled"`
+	ExpiresAt       int64             `json:"expires_at"`
+	TTL             int32             `json:"ttl"`
+	IdempotencyKey  string            `json:"idempotency_key"`
+}
+
+// parseBulkTask reads a task of a bulk enqueue. As for CreateTask a client
+// supplied task id doubles as idempotency key unless one is given.
+func parseBulkTask(data []byte) (*worker.BulkTask, error) {
+	t := bulkTask{}
+	err := json.Unmarshal(data, &t)
+	if err != nil {
+		return nil, errors.New("task is not a valid JSON object")
+	}
+	if t.Scheduled < 0 {
+		return nil, errors.New("value for scheduled is invalid")
+	}
+	if t.ExpiresAt != 0 && t.TTL != 0 {
+		return nil, errors.New("only one of expires_at and ttl can be given")
+	}
+	if t.ExpiresAt < 0 {
+		return nil, errors.New("value for expires_at is invalid")
+	}
+	if t.TTL < 0 {
+		return nil, errors.New("value for ttl is invalid")
+	}
+	task := &worker.Task{
+		ID:          t.ID,
+		Target:      t.Target,
+		Method:      t.Method,
+		Headers:     t.Headers,
+		ContentType: t.ContentType,
+		Payload:     string(t.Payload),
+		Priority:    t.Priority,
+		ExpiresAt:   t.ExpiresAt,
+	}
+	if len(t.Payload) > 0 && t.Payload[0] == '"' {
+		json.Unmarshal(t.Payload, &task.Payload)
+	} else if string(t.Payload) == "null" {
+		task.Payload = ""
+	}
+	if t.PayloadEncoding == "base64" {
+		b, err := base64.StdEncoding.DecodeString(task.Payload)
+		if err != nil {
+			return nil, errors.New("value for payload is not valid base64")
+		}
+		task.Payload = string(b)
+	} else if t.PayloadEncoding != "" {
+		return nil, errors.New("value for payload_encoding is invalid")
+	}
+	if t.TTL > 0 {
+		task.ExpiresAt = time.Now().Unix() + int64(t.TTL)
+	}
+	if t.IdempotencyKey == "" {
+		t.IdempotencyKey = task.ID
+	}
+	return &worker.BulkTask{
+		Task:           task,
+		Scheduled:      t.Scheduled,
+		IdempotencyKey: t.IdempotencyKey,
+	}, nil
+}
+
+// readBulkTasks returns the tasks of a bulk enqueue, given as a JSON array or
+// as one JSON object per line. A task that can not be read is returned as
+// an error at its position, the body as a whole only fails if it is not JSON.
+func readBulkTasks(body io.Reader) ([]*worker.BulkTask, []error, error) {
+	r := bufio.NewReader(body)
+	tasks := []*worker.BulkTask{}
+	errs := []error{}
+	add := func(data []byte) {
+		task, err := parseBulkTask(data)
+		tasks = append(tasks, task)
+		errs = append(errs, err)
+	}
+
+	// Skip to the first value to tell an array from lines of objects.
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return tasks, errs, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			r.UnreadByte()
+			break
+		}
+	}
+	if c, _ := r.Peek(1); c[0] == '[' {
+		dec := json.NewDecoder(r)
+		dec.Token()
+		for dec.More() {
+			var data json.RawMessage
+			err := dec.Decode(&data)
+			if err != nil {
+				return nil, nil, bulkArrayError(err)
+			}
+			add(data)
+		}
+		_, err := dec.Token()
+		if err != nil {
+			return nil, nil, bulkArrayError(err)
+		}
+		return tasks, errs, nil
+	}
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			add(line)
+		}
+		if err == io.EOF {
+			return tasks, errs, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// bulkArrayError returns the error of reading a bulk body given as an array.
+// Errors reading the body are passed on as they are.
+func bulkArrayError(err error) error {
+	if _, ok := err.(*json.SyntaxError); ok || err == io.ErrUnexpectedEOF || err == io.EOF {
+		return errors.New("body is not a valid JSON array")
+	}
+	return err
+}
+
+// maxBulkBytes returns the size a bulk body may have: DefaultMaxBulkBytes,
+// or more when the payload limit of all queues lets a single task take more.
+func maxBulkBytes() int64 {
+	// Payloads may double in size as JSON strings.
+	limit := 2*int64(worker.GlobalMaxPayload()) + bulkTaskOverhead
+	if limit < DefaultMaxBulkBytes {
+		limit = DefaultMaxBulkBytes
+	}
+	return limit
+}
+
+// bulkResult is the outcome of a task of a bulk enqueue, with the status
+// CreateTask would have answered for it.
+type bulkResult struct {
+	Index    int    `json:"index"`
+	ID       string `json:"id,omitempty"`
+	Status   int    `json:"status"`
+	Replayed bool   `json:"replayed,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type bulkResponse struct {
+	Object string       `json:"object"`
+	Added  int          `json:"added"`
+	Failed int          `json:"failed"`
+	Data   []bulkResult `json:"data"`
+}
+
+// API handler for POST /api/queue/{queue_id}/task/bulk.
+func createTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	vars := mux.Vars(r)
 	queueID := vars["queue_id"]
 
 	q, err := core.GetQueue(queueID)
 	if err != nil {
-		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
 		return
 	}
-	if q == nil {
-		stdhttp.Error(w, "queue id does not exsist", stdhttp.StatusBadRequest)
+	tasks, errs, err := readBulkTasks(stdhttp.MaxBytesReader(w, r.Body, maxBulkBytes()))
+	if _, ok := err.(*stdhttp.MaxBytesError); ok {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusRequestEntityTooLarge)
 		return
 	}
-	err = q.Flush()
 	if err != nil {
-		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+		return
+	}
+	if len(tasks) == 0 {
+		stdhttp.Error(w, "no tasks given", stdhttp.StatusBadRequest)
 		return
 	}
-	ReturnJSON(w, r, nil)
-}
 
-type StatsResponse struct {
-	Object                    string `json:"object"`
-	InQueue                   int64  `json:"in_queue"`
-	InProcessing              int64  `json:"in_processing"`
-	InScheduled               int64  `json:"in_scheduled"`
-	TotalReceived             int64  `json:"total_received"`
-	TotalProcessedOK          int64  `json:"total_processed_ok"`
-	TotalProcessedError       int64  `json:"total_processed_error"`
-	TotalProcessedRescheduled int64  `json:"total_processed_rescheduled"`
-}
+	valid := make([]worker.BulkTask, 0, len(tasks))
+	for i, task := range tasks {
+		if errs[i] == nil {
+			valid = append(valid, *task)
+		}
+	}
+	added := q.EnqueueBulk(valid)
 
-func (s *StatsResponse) Get(q *worker.QueueManager) {
-	stats := q.GetStats()
-	s.InQueue = stats.InQueue.Get()
-	s.InProcessing = stats.InProcessing.Get()
-	s.InScheduled = stats.InScheduled.Get()
-	s.TotalReceived = stats.TotalReceived.Get()
-	s.TotalProcessedOK = stats.TotalProcessedOK.Get()
-	s.TotalProcessedError = stats.TotalProcessedError.Get()
-	s.TotalProcessedRescheduled = stats.TotalProcessedRescheduled.Get()
+	resp := &bulkResponse{
+		Object: "bulk",
+		Data:   make([]bulkResult, len(tasks)),
+	}
+	full := false
+	for i := range tasks {
+		res := &resp.Data[i]
+		res.Index = i
+		if errs[i] != nil {
+			res.Status = stdhttp.StatusBadRequest
+			res.Error = errs[i].Error()
+			resp.Failed++
+			continue
+		}
+		a := added[0]
+		added = added[1:]
+		if a.Err != nil {
+			res.Status = enqueueErrorStatus(a.Err)
+			res.Error = a.Err.Error()
+			full = full || a.Err == worker.ErrQueueFull
+			resp.Failed++
+			continue
+		}
+		res.ID = a.Task.ID
+		res.Status = stdhttp.StatusOK
+		res.Replayed = a.Replayed
+		resp.Added++
+	}
+	if full {
+		setFullRetryAfter(w, q)
+	}
+	ReturnJSON(w, r, resp)
 }
 
-// API handler for GET /api/queue/{queue_id}/stats
-func getStats(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+// API handler for GET /api/queue/{queue_id}/task/{task_id}.
+func getTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	vars := mux.Vars(r)
 	queueID := vars["queue_id"]
 
 	q, err := core.GetQueue(queueID)
 	if err != nil {
-		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
 		return
 	}
-	if q == nil {
+	res, err := q.GetTaskDetail(vars["task_id"])
+	if err == worker.ErrTaskNotFound {
 		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
 		return
+	} else if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
 	}
+	ReturnJSON(w, r, res)
+}
 
-	statsResp := &StatsResponse{}
-	statsResp.Get(q)
+// pendingTaskError writes the response for an error returned when changing
+// or cancelling a pending task.
+func pendingTaskError(w stdhttp.ResponseWriter, err error) {
+	switch err {
+	case worker.ErrTaskNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+	case worker.ErrTaskNotPending, worker.ErrTaskProcessing, worker.ErrTaskNotScheduled,
+		worker.ErrTaskNotWaiting:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusConflict)
+	case worker.ErrTaskInvalidSchedule, worker.ErrTaskInvalidTarget, worker.ErrTargetScheme, worker.ErrTargetHostDenied,
+		worker.ErrTargetPayloadSize, worker.ErrCommandNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+	default:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+	}
+}
+
+// API handler for PATCH /api/queue/{queue_id}/task/{task_id}. Changes the
+// target, payload or scheduled time of a waiting or scheduled task, only the
+// given fields are changed.
+func updateTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = r.ParseForm()
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+		return
+	}
+	u := &worker.TaskUpdate{}
+	if _, ok := r.Form["target"]; ok {
+		target := r.FormValue("target")
+		u.Target = &target
+	}
+	if _, ok := r.Form["payload"]; ok {
+		payload := r.FormValue("payload")
+		u.Payload = &payload
+	}
+	if _, ok := r.Form["scheduled"]; ok {
+		scheduled, err := strconv.ParseInt(r.FormValue("scheduled"), 10, 64)
+		if err != nil || scheduled < 0 {
+			stdhttp.Error(w, "value for scheduled is invalid", stdhttp.StatusBadRequest)
+			return
+		}
+		u.Scheduled = &scheduled
+	}
+	res, err := q.UpdateTask(vars["task_id"], u)
+	if err != nil {
+		pendingTaskError(w, err)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// API handler for DELETE /api/queue/{queue_id}/task/{task_id}. Cancels a
+// waiting or scheduled task.
+func cancelTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.CancelTask(vars["task_id"])
+	if err != nil {
+		pendingTaskError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for POST /api/queue/{queue_id}/task/{task_id}/run. Moves a
+// scheduled task to the wait queue at once.
+func runTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.RunTask(vars["task_id"])
+	if err != nil {
+		pendingTaskError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for POST /api/queue/{queue_id}/scheduled/run. Moves all
+// scheduled tasks to the wait queue at once.
+func runScheduledTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	n, err := q.RunScheduledTasks()
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, &countResponse{Object: "run", Count: n})
+}
+
+// API handler for POST /api/queue/{queue_id}/task/{task_id}/defer. Moves a
+// waiting task to the schedule queue until the given unix time.
+func deferTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	scheduled, err := strconv.ParseInt(r.FormValue("scheduled"), 10, 64)
+	if err != nil {
+		stdhttp.Error(w, "value for scheduled is invalid", stdhttp.StatusBadRequest)
+		return
+	}
+	err = q.DeferTask(vars["task_id"], scheduled)
+	if err != nil {
+		pendingTaskError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// parseVisibility reads a lease visibility given as a duration such as 30s or
+// 5m, or as a number of seconds.
+func parseVisibility(value string) (time.Duration, error) {
+	if value == "" {
+		return worker.DefaultLeaseVisibility, nil
+	}
+	if v, err := strconv.Atoi(value); err == nil {
+		return time.Duration(v) * time.Second, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, worker.ErrLeaseInvalidVisibility
+	}
+	return d, nil
+}
+
+// leaseError writes the response for an error returned by a lease call.
+func leaseError(w stdhttp.ResponseWriter, err error) {
+	switch err {
+	case worker.ErrLeaseNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+	case worker.ErrLeaseNotPull:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusConflict)
+	case worker.ErrLeaseInvalidCount, worker.ErrLeaseInvalidVisibility:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+	default:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+	}
+}
+
+// API handler for POST /api/queue/{queue_id}/lease.
+func leaseTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	count := 1
+	if r.FormValue("count") != "" {
+		count, err = strconv.Atoi(r.FormValue("count"))
+		if err != nil {
+			leaseError(w, worker.ErrLeaseInvalidCount)
+			return
+		}
+	}
+	visibility, err := parseVisibility(r.FormValue("visibility"))
+	if err != nil {
+		leaseError(w, err)
+		return
+	}
+	res, err := q.Lease(count, visibility)
+	if err != nil {
+		leaseError(w, err)
+		return
+	}
+	ReturnJSON(w, r, JSONListResult("/api/queue/"+queueID+"/lease", len(res), res))
+}
+
+// API handler for POST /api/queue/{queue_id}/task/{task_id}/ack.
+func ackTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.Ack(vars["task_id"], r.FormValue("lease_id"), r.FormValue("result"))
+	if err != nil {
+		leaseError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for POST /api/queue/{queue_id}/task/{task_id}/nack.
+func nackTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.Nack(vars["task_id"], r.FormValue("lease_id"), r.FormValue("error"))
+	if err != nil {
+		leaseError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for POST /api/queue/{queue_id}/task/{task_id}/extend.
+func extendLease(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	visibility, err := parseVisibility(r.FormValue("visibility"))
+	if err != nil {
+		leaseError(w, err)
+		return
+	}
+	res, err := q.ExtendLease(vars["task_id"], r.FormValue("lease_id"), visibility)
+	if err != nil {
+		leaseError(w, err)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// parseCronJob reads a cron job from the form values. The task fields are
+// the same as when creating a task.
+func parseCronJob(r *stdhttp.Request, cronID string) (*worker.CronJob, error) {
+	task, ttl, err := parseTask(r)
+	if err != nil {
+		return nil, err
+	}
+	if task.ID != "" || task.ExpiresAt != 0 {
+		return nil, errors.New("task_id and expires_at can not be given for cron jobs, use ttl")
+	}
+	return &worker.CronJob{
+		ID:       cronID,
+		Schedule: r.FormValue("schedule"),
+		Timezone: r.FormValue("timezone"),
+		Missed:   r.FormValue("missed"),
+		TTL:      ttl,
+		Task:     *task,
+	}, nil
+}
+
+// cronError writes the response for an error returned by a cron job call.
+func cronError(w stdhttp.ResponseWriter, err error) {
+	switch err {
+	case worker.ErrCronNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+	case worker.ErrCronAlreadyExist:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusConflict)
+	case worker.ErrCronInvalidID, worker.ErrCronInvalidSchedule, worker.ErrCronInvalidTimezone, worker.ErrCronNeverRuns,
+		worker.ErrCronInvalidMissed, worker.ErrCronInvalidTTL, worker.ErrTaskInvalidMethod,
+		worker.ErrTaskInvalidHeader, worker.ErrTaskInvalidPriority, worker.ErrTaskInvalidTarget,
+		worker.ErrTargetScheme, worker.ErrTargetHostDenied, worker.ErrTargetPayloadSize,
+		worker.ErrCommandNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+	default:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+	}
+}
+
+// API handler for GET /api/queue/{queue_id}/cron.
+func getAllCronJobs(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	res, err := q.GetCronJobs()
+	if err != nil {
+		cronError(w, err)
+		return
+	}
+	ReturnJSON(w, r, JSONListResult("/api/queue/"+queueID+"/cron", len(res), res))
+}
+
+// API handler for POST /api/queue/{queue_id}/cron.
+func createCronJob(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	job, err := parseCronJob(r, r.FormValue("cron_id"))
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+		return
+	}
+	err = q.AddCronJob(job)
+	if err != nil {
+		cronError(w, err)
+		return
+	}
+	ReturnJSON(w, r, job)
+}
+
+// API handler for GET /api/queue/{queue_id}/cron/{cron_id}.
+func getCronJob(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	res, err := q.GetCronJob(vars["cron_id"])
+	if err != nil {
+		cronError(w, err)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// API handler for PUT /api/queue/{queue_id}/cron/{cron_id}. The job is
+// replaced as a whole.
+func updateCronJob(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	job, err := parseCronJob(r, vars["cron_id"])
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+		return
+	}
+	err = q.UpdateCronJob(job)
+	if err != nil {
+		cronError(w, err)
+		return
+	}
+	ReturnJSON(w, r, job)
+}
+
+// API handler for DELETE /api/queue/{queue_id}/cron/{cron_id}.
+func deleteCronJob(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.DeleteCronJob(vars["cron_id"])
+	if err != nil {
+		cronError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// batchError writes the response for an error returned by a batch call.
+func batchError(w stdhttp.ResponseWriter, err error) {
+	switch err {
+	case worker.ErrBatchNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+	case worker.ErrBatchAlreadyExist:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusConflict)
+	case worker.ErrBatchInvalidID, worker.ErrBatchInvalidCallback, worker.ErrTaskInvalidTarget,
+		worker.ErrTargetScheme, worker.ErrTargetHostDenied, worker.ErrCommandNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+	default:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+	}
+}
+
+// API handler for GET /api/queue/{queue_id}/batch.
+func getAllBatches(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	res, err := q.GetBatches()
+	if err != nil {
+		batchError(w, err)
+		return
+	}
+	ReturnJSON(w, r, JSONListResult("/api/queue/"+queueID+"/batch", len(res), res))
+}
+
+// API handler for POST /api/queue/{queue_id}/batch.
+func createBatch(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	batch := &worker.Batch{
+		ID:       r.FormValue("batch_id"),
+		Callback: r.FormValue("callback"),
+	}
+	err = q.CreateBatch(batch)
+	if err != nil {
+		batchError(w, err)
+		return
+	}
+	ReturnJSON(w, r, batch)
+}
+
+// API handler for GET /api/queue/{queue_id}/batch/{batch_id}.
+func getBatch(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	res, err := q.GetBatch(vars["batch_id"])
+	if err != nil {
+		batchError(w, err)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// API handler for POST /api/queue/{queue_id}/batch/{batch_id}/close.
+func closeBatch(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	res, err := q.CloseBatch(vars["batch_id"])
+	if err != nil {
+		batchError(w, err)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// API handler for DELETE /api/queue/{queue_id}/batch/{batch_id}.
+func deleteBatch(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.DeleteBatch(vars["batch_id"])
+	if err != nil {
+		batchError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for GET /api/pipeline/{pipeline_id}.
+func getPipeline(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	res, err := core.GetPipeline(vars["pipeline_id"])
+	if err == worker.ErrPipelineNotFound {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	} else if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// hostLimitError writes the response for an error returned by a host limit
+// call.
+func hostLimitError(w stdhttp.ResponseWriter, err error) {
+	switch err {
+	case worker.ErrHostLimitNotFound:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+	case worker.ErrHostLimitInvalidHost, worker.ErrHostLimitInvalid:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+	default:
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+	}
+}
+
+// API handler for GET /api/host.
+func getAllHostLimits(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	res, err := core.GetHostLimits()
+	if err != nil {
+		hostLimitError(w, err)
+		return
+	}
+	ReturnJSON(w, r, JSONListResult("/api/host", len(res), res))
+}
+
+// API handler for POST /api/host. Adds a limit or replaces the limit on the
+// same host.
+func setHostLimit(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	limit := &worker.HostLimit{
+		Host: r.FormValue("host"),
+	}
+	ints := []struct {
+		name  string
+		value *int32
+	}{
+		{"max_concurrent", &limit.MaxConcurrent},
+		{"max_rate", &limit.MaxRate},
+	}
+	for _, f := range ints {
+		if r.FormValue(f.name) == "" {
+			continue
+		}
+		v, err := strconv.Atoi(r.FormValue(f.name))
+		if err != nil {
+			stdhttp.Error(w, fmt.Sprintf("invalid %s", f.name), stdhttp.StatusBadRequest)
+			return
+		}
+		*f.value = int32(v)
+	}
+	err := core.SetHostLimit(limit)
+	if err != nil {
+		hostLimitError(w, err)
+		return
+	}
+	ReturnJSON(w, r, limit)
+}
+
+// API handler for GET /api/host/{host}.
+func getHostLimit(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	res, err := core.GetHostLimit(mux.Vars(r)["host"])
+	if err != nil {
+		hostLimitError(w, err)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// API handler for DELETE /api/host/{host}.
+func deleteHostLimit(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	err := core.DeleteHostLimit(mux.Vars(r)["host"])
+	if err != nil {
+		hostLimitError(w, err)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for DELETE /api/queue/{queue_id}/task.
+func deleteAllTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	if q == nil {
+		stdhttp.Error(w, "queue id does not exsist", stdhttp.StatusBadRequest)
+		return
+	}
+	err = q.Flush()
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+type StatsResponse struct {
+	Object                    string                `json:"object"`
+	InQueue                   int64                 `json:"in_queue"`
+	InProcessing              int64                 `json:"in_processing"`
+	InScheduled               int64                 `json:"in_scheduled"`
+	InDead                    int64                 `json:"in_dead"`
+	TotalReceived             int64                 `json:"total_received"`
+	TotalProcessedOK          int64                 `json:"total_processed_ok"`
+	TotalProcessedError       int64                 `json:"total_processed_error"`
+	TotalProcessedRescheduled int64                 `json:"total_processed_rescheduled"`
+	TotalDead                 int64                 `json:"total_dead"`
+	TotalExpired              int64                 `json:"total_expired"`
+	TotalDeferred             int64                 `json:"total_deferred"` // Tasks put back while their target host's breaker was open.
+	TotalRejected             int64                 `json:"total_rejected"` // Tasks turned away by a full queue.
+	TotalDropped              int64                 `json:"total_dropped"`  // Oldest tasks dropped to make room for new ones.
+	PendingBytes              int64                 `json:"pending_bytes"`  // Payload bytes of the waiting and scheduled tasks.
+	HeldFor                   int64                 `json:"held_for"`       // Seconds left of a Retry-After hold.
+	Paused                    bool                  `json:"paused"`
+	RateLimit                 worker.RateLimitState `json:"rate_limit"`
+	Breakers                  []worker.BreakerState `json:"breakers"`
+}
+
+func (s *StatsResponse) Get(q *worker.QueueManager) {
+	stats := q.GetStats()
+	s.InQueue = stats.InQueue.Get()
+	s.InProcessing = stats.InProcessing.Get()
+	s.InScheduled = stats.InScheduled.Get()
+	s.InDead = stats.InDead.Get()
+	s.TotalReceived = stats.TotalReceived.Get()
+	s.TotalProcessedOK = stats.TotalProcessedOK.Get()
+	s.TotalProcessedError = stats.TotalProcessedError.Get()
+	s.TotalProcessedRescheduled = stats.TotalProcessedRescheduled.Get()
+	s.TotalDead = stats.TotalDead.Get()
+	s.TotalExpired = stats.TotalExpired.Get()
+	s.TotalDeferred = stats.TotalDeferred.Get()
+	s.TotalRejected = stats.TotalRejected.Get()
+	s.TotalDropped = stats.TotalDropped.Get()
+	s.PendingBytes = stats.PendingBytes.Get()
+	s.HeldFor = int64((q.HeldFor() + time.Second - 1) / time.Second)
+	s.Paused = q.IsPaused()
+	s.RateLimit = q.RateLimit()
+	s.Breakers = q.Breakers()
+}
+
+// API handler for GET /api/queue/{queue_id}/stats
+func getStats(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	if q == nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+
+	statsResp := &StatsResponse{}
+	statsResp.Get(q)
 	ReturnJSON(w, r, statsResp)
 }
+
+type countResponse struct {
+	Object string `json:"object"`
+	Count  int    `json:"count"`
+}
+
+// API handler for GET /api/queue/{queue_id}/dead
+func getAllDeadTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	res, err := q.GetDeadTasks()
+	if err != nil {
+		stdhttp.Error(w, "could not fetch dead tasks", stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, JSONListResult("/api/queue/"+queueID+"/dead", len(*res), res))
+}
+
+// API handler for GET /api/queue/{queue_id}/dead/{task_id}
+func getDeadTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	res, err := q.GetDeadTask(vars["task_id"])
+	if err == worker.ErrTaskNotFound {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	} else if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, res)
+}
+
+// API handler for POST /api/queue/{queue_id}/dead/{task_id}/requeue
+func requeueDeadTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.RequeueDeadTask(vars["task_id"])
+	if err == worker.ErrTaskNotFound {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	} else if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for POST /api/queue/{queue_id}/dead/requeue
+func requeueAllDeadTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	n, err := q.RequeueDeadTasks()
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, &countResponse{Object: "requeued", Count: n})
+}
+
+// API handler for DELETE /api/queue/{queue_id}/dead/{task_id}
+func deleteDeadTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.DeleteDeadTask(vars["task_id"])
+	if err == worker.ErrTaskNotFound {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	} else if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, nil)
+}
+
+// API handler for DELETE /api/queue/{queue_id}/dead
+func purgeDeadTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	n, err := q.PurgeDeadTasks()
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	ReturnJSON(w, r, &countResponse{Object: "purged", Count: n})
+}
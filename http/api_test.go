@@ -0,0 +1,90 @@
+package http
+
+import (
+	"io/ioutil"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadBulkTasks(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		payloads []string // Payload of each task, "!" for one failing to parse.
+		err      bool
+	}{
+		{"empty", "", []string{}, false},
+		{"blank", " \n\t\r\n", []string{}, false},
+		{"empty array", "[]", []string{}, false},
+		{"array", `[{"target": "http://a/", "payload": "x"}, {"target": "http://b/", "payload": {"foo": 1}}]`,
+			[]string{"x", `{"foo": 1}`}, false},
+		{"array after blanks", "\n  [{\"target\": \"http://a/\"}]", []string{""}, false},
+		{"lines", "{\"target\": \"http://a/\", \"payload\": \"x\"}\n\n{\"target\": \"http://b/\", \"payload\": [1, 2]}",
+			[]string{"x", "[1, 2]"}, false},
+		{"lines with bad task", "{\"target\": \"http://a/\"}\nnot json\n{\"target\": \"http://b/\", \"payload\": null}\n",
+			[]string{"", "!", ""}, false},
+		{"array with bad task", `[{"target": "http://a/"}, 1, {"scheduled": -1}]`, []string{"", "!", "!"}, false},
+		{"base64", `[{"target": "http://a/", "payload": "AP8=", "payload_encoding": "base64"}, {"payload": "x", "payload_encoding": "hex"}]`,
+			[]string{"\x00\xff", "!"}, false},
+		{"unterminated array", `[{"target": "http://a/"}`, nil, true},
+		{"broken array", `[{"target": "http://a/"} {"target": "http://b/"}]`, nil, true},
+	}
+	for _, test := range tests {
+		tasks, errs, err := readBulkTasks(strings.NewReader(test.body))
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: Expected error, got %d task(s)", test.name, len(tasks))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Expected no error, got %s", test.name, err)
+			continue
+		}
+		if len(tasks) != len(test.payloads) || len(errs) != len(test.payloads) {
+			t.Errorf("%s: Expected %d task(s), got %d", test.name, len(test.payloads), len(tasks))
+			continue
+		}
+		for i, want := range test.payloads {
+			if want == "!" {
+				if errs[i] == nil {
+					t.Errorf("%s: Expected error for task %d, got %+v", test.name, i, tasks[i].Task)
+				}
+				continue
+			}
+			if errs[i] != nil {
+				t.Errorf("%s: Expected task %d, got %s", test.name, i, errs[i])
+			} else if got := tasks[i].Task.Payload; got != want {
+				t.Errorf("%s: Expected payload %q for task %d, got %q", test.name, want, i, got)
+			}
+		}
+	}
+}
+
+func TestReadBulkTasksIdempotencyKey(t *testing.T) {
+	tasks, _, err := readBulkTasks(strings.NewReader(
+		`[{"task_id": "a"}, {"task_id": "b", "idempotency_key": "k"}, {}]`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	for i, want := range []string{"a", "k", ""} {
+		if got := tasks[i].IdempotencyKey; got != want {
+			t.Errorf("Expected idempotency key %q for task %d, got %q", want, i, got)
+		}
+	}
+}
+
+func TestReadBulkTasksTooLarge(t *testing.T) {
+	for _, body := range []string{
+		`[{"target": "http://a/", "payload": "` + strings.Repeat("x", 100) + `"}]`,
+		`{"target": "http://a/", "payload": "` + strings.Repeat("x", 100) + `"}`,
+	} {
+		r := stdhttp.MaxBytesReader(httptest.NewRecorder(), ioutil.NopCloser(strings.NewReader(body)), 64)
+		_, _, err := readBulkTasks(r)
+		if _, ok := err.(*stdhttp.MaxBytesError); !ok {
+			t.Errorf("Expected MaxBytesError for %q, got %v", body, err)
+		}
+	}
+}
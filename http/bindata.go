@@ -29300,122 +29300,146 @@ func static_normalize_css() ([]byte, error) {
 
 func static_style_css() ([]byte, error) {
 	return bindata_read([]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x00, 0xff, 0xb4, 0x58,
-		0x7d, 0x6f, 0xa3, 0x36, 0x18, 0xff, 0x3f, 0x9f, 0x02, 0xf5, 0x34, 0x69,
-		0x93, 0x02, 0x22, 0x34, 0x49, 0x53, 0x22, 0x4d, 0xba, 0x4b, 0x7b, 0xda,
-		0x5f, 0xfb, 0x0e, 0x06, 0x3b, 0xc5, 0x3a, 0x07, 0x33, 0x63, 0x92, 0x76,
-		0x53, 0xbf, 0xfb, 0xfc, 0x0a, 0xd8, 0x18, 0xae, 0x9d, 0xb4, 0x3e, 0xaa,
-		0x14, 0x8c, 0x9f, 0x17, 0xff, 0x9e, 0x57, 0x53, 0x50, 0xf8, 0x16, 0xfd,
-		0xb3, 0x8a, 0xa2, 0x0b, 0x60, 0x2f, 0xb8, 0xce, 0xa3, 0xf4, 0x28, 0x1e,
-		0x0a, 0x50, 0xfe, 0x78, 0x61, 0xb4, 0xab, 0x61, 0x5c, 0x52, 0x42, 0x59,
-		0x1e, 0x7d, 0xf9, 0xae, 0xfe, 0xe4, 0xcb, 0x33, 0xad, 0x79, 0x7c, 0x06,
-		0x17, 0x4c, 0xde, 0xf2, 0xe8, 0xee, 0x0f, 0x44, 0xae, 0x88, 0xe3, 0x12,
-		0x44, 0x7f, 0xa2, 0x0e, 0xdd, 0xad, 0xfb, 0xe7, 0xf5, 0x57, 0x86, 0x01,
-		0x59, 0xb7, 0xa0, 0x6e, 0xe3, 0x16, 0x31, 0x7c, 0x3e, 0xae, 0xde, 0x57,
-		0xd5, 0x46, 0x29, 0x53, 0x22, 0x5a, 0xfc, 0x37, 0xca, 0xa3, 0x4d, 0xb2,
-		0x67, 0xe8, 0x72, 0xf4, 0x2d, 0xd0, 0x0f, 0x71, 0x41, 0x39, 0xa7, 0x17,
-		0xb1, 0x96, 0x1c, 0xcc, 0x2e, 0xc5, 0x7a, 0x43, 0xf8, 0xa5, 0xe2, 0x79,
-		0x54, 0x53, 0x76, 0x01, 0x44, 0x09, 0xce, 0x26, 0x82, 0x0f, 0xcd, 0xab,
-		0x64, 0xb0, 0x07, 0x78, 0x54, 0x7f, 0x8e, 0x22, 0x41, 0x9b, 0xb4, 0x79,
-		0x95, 0x1a, 0xdf, 0x57, 0x1d, 0x51, 0x12, 0x08, 0x6e, 0x85, 0x04, 0xfe,
-		0x46, 0x50, 0xcc, 0xdf, 0x1a, 0x24, 0x75, 0xd4, 0x68, 0x62, 0x5e, 0x03,
-		0x20, 0xc4, 0xf5, 0x4b, 0xae, 0x59, 0x6b, 0x70, 0x55, 0xbc, 0x0d, 0x6d,
-		0x31, 0xc7, 0x54, 0x6c, 0x02, 0x45, 0x4b, 0x49, 0xc7, 0x15, 0x23, 0xa7,
-		0x8d, 0xe1, 0x22, 0xe8, 0xcc, 0x2d, 0xc2, 0xf6, 0x60, 0x33, 0x70, 0x67,
-		0xbb, 0xec, 0x90, 0x3d, 0xc8, 0x97, 0x37, 0x0c, 0x79, 0x95, 0x47, 0x5b,
-		0x73, 0x9c, 0x5e, 0xb3, 0x7f, 0x3e, 0xf0, 0x00, 0x8a, 0x62, 0x23, 0x57,
-		0xe8, 0x15, 0xb1, 0x33, 0xa1, 0xb7, 0x3c, 0xaa, 0x30, 0x84, 0xa8, 0xb6,
-		0x26, 0x26, 0xc2, 0x92, 0xb5, 0xfe, 0xa5, 0xd5, 0x2f, 0x18, 0x3d, 0x32,
-		0x75, 0xd9, 0x80, 0x41, 0xb4, 0x92, 0x36, 0x1c, 0xb6, 0x32, 0x3e, 0xda,
-		0xa7, 0xa3, 0x6d, 0x23, 0xbd, 0x0e, 0x02, 0x76, 0xf3, 0x36, 0xf5, 0x64,
-		0x02, 0xdf, 0xad, 0xd9, 0x56, 0x9b, 0xe1, 0xc4, 0x41, 0x41, 0x09, 0x9c,
-		0xf8, 0x88, 0xa3, 0x57, 0x1e, 0x03, 0x82, 0x5f, 0xc4, 0x42, 0x89, 0x6a,
-		0x8e, 0xd8, 0x18, 0xae, 0x54, 0xfd, 0xf5, 0xfb, 0x20, 0x2a, 0x29, 0x03,
-		0x1a, 0x07, 0xeb, 0x71, 0xda, 0x80, 0x12, 0xf3, 0x37, 0x19, 0x7d, 0x5b,
-		0xf9, 0x0c, 0x71, 0xdb, 0x10, 0x20, 0x9e, 0x0b, 0x42, 0xcb, 0x1f, 0xd2,
-		0xcc, 0x44, 0x40, 0x87, 0x62, 0xeb, 0x7e, 0x13, 0xb3, 0x0a, 0x82, 0x6d,
-		0xe6, 0xa0, 0x15, 0x6b, 0x3c, 0x37, 0xde, 0x2a, 0xd3, 0xe6, 0xeb, 0xe5,
-		0xb1, 0xb8, 0xe9, 0xb1, 0xf7, 0xae, 0xb7, 0x77, 0x1b, 0x49, 0x0b, 0xe6,
-		0x3b, 0xd2, 0x12, 0x50, 0x72, 0x7c, 0x45, 0x4a, 0x68, 0x1f, 0x61, 0xa7,
-		0x87, 0xdd, 0x61, 0xef, 0xab, 0xfd, 0x3d, 0x6a, 0x1b, 0x50, 0x8f, 0x8f,
-		0x63, 0x6c, 0xdc, 0x6a, 0x13, 0x2f, 0x00, 0x3b, 0x6f, 0xf5, 0xb9, 0x0e,
-		0xc6, 0x29, 0x36, 0xf2, 0x62, 0x01, 0x12, 0xe8, 0x38, 0x3d, 0x7e, 0x20,
-		0x29, 0x9c, 0x38, 0x18, 0x85, 0x9d, 0xd1, 0xfb, 0xb3, 0x7a, 0x64, 0x4c,
-		0xea, 0x03, 0xd0, 0xb1, 0xcb, 0xe6, 0x88, 0x0d, 0x2f, 0x1b, 0x3c, 0x43,
-		0x0c, 0xa7, 0x7a, 0x61, 0xb1, 0xe0, 0xf5, 0x7e, 0xb7, 0xc8, 0x1a, 0x8d,
-		0x98, 0x13, 0x64, 0x3c, 0x65, 0x99, 0x9e, 0xee, 0x25, 0x2d, 0xba, 0x45,
-		0x33, 0x97, 0xc2, 0xb5, 0x22, 0x26, 0x75, 0x06, 0x8e, 0x33, 0x2a, 0xba,
-		0x37, 0x29, 0xc0, 0x41, 0x41, 0xb4, 0xc7, 0x4c, 0x02, 0x6e, 0xd2, 0xf4,
-		0x17, 0x25, 0x59, 0xbe, 0x88, 0x85, 0x41, 0xb4, 0x13, 0x47, 0x3a, 0xe3,
-		0x57, 0x04, 0x87, 0xfd, 0xbc, 0x72, 0xec, 0xd9, 0xdf, 0x4b, 0x5a, 0xa8,
-		0x9b, 0x96, 0x6b, 0x1d, 0x99, 0x5f, 0x50, 0xa7, 0xf1, 0x28, 0x77, 0x24,
-		0x94, 0x0e, 0x68, 0x22, 0x14, 0xfb, 0xff, 0x74, 0x24, 0x44, 0xf5, 0x12,
-		0xce, 0xf2, 0x9a, 0x57, 0x71, 0x59, 0x61, 0x02, 0x7f, 0xa5, 0x10, 0xfe,
-		0xa6, 0x93, 0x3d, 0x80, 0xef, 0x49, 0xd2, 0xc0, 0xee, 0x02, 0x69, 0xd3,
-		0xf3, 0x7d, 0x05, 0x89, 0xd3, 0x9f, 0x76, 0x52, 0xa9, 0x24, 0xf3, 0x0e,
-		0x6a, 0x0c, 0xfd, 0xdc, 0xf4, 0x6a, 0x81, 0x08, 0x4a, 0xc0, 0x87, 0xa3,
-		0xb8, 0xa1, 0x6d, 0x83, 0xc0, 0xef, 0x14, 0x5a, 0x3e, 0x74, 0xdb, 0xa3,
-		0xa2, 0x2c, 0xf3, 0xe2, 0xfd, 0x75, 0xa8, 0xb5, 0x06, 0xbc, 0xa1, 0x08,
-		0x23, 0x42, 0x70, 0xd3, 0xe2, 0xd6, 0xaf, 0x49, 0x4a, 0xbb, 0xd4, 0xb2,
-		0x4a, 0x20, 0x68, 0xab, 0x82, 0x02, 0x06, 0xa3, 0xa4, 0x46, 0x37, 0xa5,
-		0x70, 0x5a, 0xc4, 0x27, 0x4d, 0xd1, 0x46, 0x8a, 0xcf, 0x0e, 0x66, 0x10,
-		0xf1, 0x40, 0x28, 0x28, 0x83, 0x88, 0x0d, 0xe5, 0xae, 0xf7, 0xaf, 0x8c,
-		0x42, 0x5b, 0xa9, 0xf4, 0xa6, 0x98, 0x01, 0x88, 0xbb, 0x56, 0x24, 0x90,
-		0x57, 0x85, 0x4e, 0x4f, 0xd9, 0xe9, 0x69, 0xae, 0x85, 0x99, 0x02, 0xb3,
-		0x54, 0xa2, 0x06, 0xcb, 0xc1, 0x10, 0x77, 0x3f, 0xd9, 0x99, 0xb4, 0x1c,
-		0xf0, 0xae, 0x5d, 0x3b, 0x6b, 0x0d, 0xa3, 0x25, 0x6a, 0x5b, 0x61, 0xbf,
-		0xbb, 0x7e, 0x03, 0xa2, 0xf8, 0xf8, 0x8b, 0x6d, 0x59, 0x21, 0xd8, 0x11,
-		0x04, 0xdd, 0xf4, 0x52, 0xd9, 0x75, 0x11, 0x00, 0x0f, 0x09, 0x17, 0x00,
-		0x98, 0xc6, 0x7f, 0x75, 0x62, 0xd2, 0x69, 0x27, 0x79, 0x32, 0xf4, 0x98,
-		0x71, 0x23, 0xc8, 0x96, 0x85, 0x60, 0xbf, 0xd0, 0x6f, 0xbd, 0x60, 0x7c,
-		0xde, 0x49, 0x9a, 0x17, 0xd0, 0xb8, 0xf5, 0xe7, 0x9b, 0xa4, 0xa3, 0xd7,
-		0x3b, 0x8c, 0xd7, 0xc6, 0x66, 0x6d, 0x4c, 0x3d, 0x5f, 0x25, 0x25, 0x43,
-		0x40, 0x94, 0x7f, 0x25, 0x4e, 0x70, 0xb1, 0xcb, 0xa4, 0x97, 0x65, 0xf6,
-		0x04, 0xce, 0xd6, 0x04, 0xd7, 0x4d, 0xc7, 0xc7, 0x9b, 0x6d, 0x5c, 0x5a,
-		0xd9, 0xc1, 0xfd, 0x03, 0x97, 0x45, 0x79, 0xbf, 0x20, 0x3e, 0xc1, 0x70,
-		0xca, 0xb1, 0x4d, 0x83, 0x1c, 0x04, 0x14, 0x88, 0xac, 0xbd, 0xc5, 0x81,
-		0x39, 0xd0, 0xbd, 0xa7, 0xec, 0xbe, 0x37, 0xd2, 0xe4, 0xd1, 0xcc, 0x9d,
-		0x7d, 0x45, 0xfd, 0x2e, 0xa9, 0x8f, 0x6a, 0xce, 0xc4, 0x7c, 0x2b, 0x51,
-		0xcb, 0xa3, 0xae, 0x69, 0x10, 0x2b, 0x41, 0x8b, 0x02, 0xa9, 0x1a, 0x44,
-		0x64, 0x16, 0xc0, 0xed, 0x38, 0xf7, 0x04, 0x42, 0x22, 0x1f, 0x45, 0xe7,
-		0x14, 0x50, 0x7c, 0x79, 0xfa, 0x2a, 0xc9, 0xc9, 0xd6, 0xad, 0xcc, 0xd6,
-		0x20, 0x20, 0xcd, 0x74, 0xb0, 0xb7, 0xa7, 0xf8, 0x7a, 0x90, 0x74, 0xf4,
-		0x0f, 0xab, 0x87, 0xec, 0x89, 0x2b, 0x8a, 0x4e, 0xd8, 0x55, 0xb7, 0xd3,
-		0x21, 0x27, 0xa8, 0x56, 0xef, 0x36, 0x33, 0xde, 0x42, 0x89, 0xb1, 0x23,
-		0xcd, 0xff, 0x59, 0x62, 0x26, 0xa5, 0x3e, 0xe8, 0x08, 0x6d, 0x71, 0x52,
-		0x82, 0xba, 0x34, 0x21, 0x10, 0xea, 0x57, 0x0f, 0x92, 0xc6, 0xa6, 0x9d,
-		0x0e, 0x92, 0x74, 0x16, 0x5d, 0x31, 0xba, 0x59, 0xb8, 0x5a, 0x54, 0x4a,
-		0x1b, 0x82, 0x9e, 0xdd, 0x1b, 0xfd, 0xce, 0xfe, 0xae, 0x88, 0x17, 0x78,
-		0x7a, 0xe7, 0x06, 0x74, 0xc4, 0x43, 0xd4, 0x1a, 0x18, 0x2d, 0x57, 0x36,
-		0x04, 0xcd, 0xf3, 0x49, 0xd2, 0xc4, 0xd9, 0x8f, 0xce, 0xbd, 0x6b, 0x50,
-		0xb7, 0x77, 0x67, 0x54, 0x3f, 0x84, 0x5d, 0x2b, 0x50, 0x3d, 0x99, 0x54,
-		0x53, 0xa7, 0xda, 0xf4, 0xf6, 0x78, 0x75, 0x6d, 0xff, 0x2c, 0x29, 0x2c,
-		0xb1, 0x1f, 0x41, 0x47, 0x62, 0xef, 0x43, 0xc8, 0x95, 0x15, 0x60, 0x62,
-		0xaa, 0x61, 0x40, 0xa6, 0x5e, 0x10, 0xbb, 0x9f, 0x73, 0xcd, 0x42, 0x39,
-		0x89, 0xa1, 0x25, 0x24, 0x94, 0xcc, 0x99, 0xc6, 0x1d, 0xdc, 0x9a, 0x68,
-		0x75, 0x9c, 0xcd, 0xc6, 0x5b, 0x3f, 0xe0, 0x2e, 0x70, 0x4f, 0xa7, 0x35,
-		0xdb, 0x85, 0x3c, 0xb6, 0x02, 0x30, 0x07, 0xa7, 0x90, 0xc2, 0xad, 0x24,
-		0x67, 0x5c, 0x36, 0xa1, 0xe0, 0x0c, 0xa0, 0x53, 0xc1, 0xda, 0x55, 0xee,
-		0x70, 0xe1, 0x8b, 0x98, 0xa9, 0x70, 0x01, 0x2b, 0x76, 0x92, 0x82, 0xf6,
-		0x4b, 0xd9, 0x73, 0xc6, 0xdf, 0x3f, 0x3c, 0x67, 0x8f, 0xdf, 0x82, 0x6c,
-		0x2a, 0xf5, 0xe7, 0xf8, 0x76, 0xd9, 0xfe, 0xfb, 0xc3, 0x69, 0x19, 0xe5,
-		0x71, 0x83, 0xfd, 0xf6, 0x2c, 0x69, 0x79, 0xbf, 0x1b, 0xbe, 0xfe, 0xcc,
-		0x1d, 0xbc, 0x58, 0x7d, 0x4a, 0xc1, 0x00, 0x84, 0x03, 0xfa, 0x12, 0xc7,
-		0x80, 0x81, 0x61, 0xe9, 0x87, 0x4f, 0x97, 0x87, 0xd6, 0x67, 0xfc, 0x12,
-		0x05, 0xaf, 0x12, 0x73, 0x86, 0x39, 0x2c, 0x9f, 0x8e, 0x66, 0x87, 0x7b,
-		0xee, 0xee, 0xe1, 0x31, 0x89, 0x18, 0x96, 0x1d, 0x57, 0x56, 0xeb, 0x4f,
-		0x99, 0x1a, 0xe0, 0xfb, 0xac, 0xbd, 0x01, 0x11, 0xff, 0xdd, 0x68, 0x31,
-		0xc7, 0x5d, 0x01, 0xe9, 0xd0, 0xa4, 0x82, 0xba, 0xf3, 0x9a, 0x89, 0x93,
-		0x2c, 0x50, 0x75, 0x84, 0xa0, 0xf6, 0xc3, 0xc5, 0xff, 0x23, 0xa5, 0x51,
-		0x09, 0x9c, 0xbb, 0x3f, 0x0c, 0x37, 0x79, 0x86, 0x08, 0x90, 0x1f, 0x14,
-		0x02, 0xb7, 0x8a, 0xd9, 0x46, 0x3c, 0xba, 0xd9, 0x05, 0x0e, 0x67, 0xca,
-		0xc4, 0xe2, 0x4c, 0x15, 0xec, 0x5e, 0x1f, 0x69, 0x53, 0xfa, 0x54, 0xe6,
-		0x23, 0x48, 0x0e, 0xce, 0x7c, 0x52, 0x04, 0xdd, 0x41, 0xc3, 0x88, 0x8a,
-		0xfb, 0x51, 0x44, 0xdd, 0xd5, 0xf3, 0xe8, 0xee, 0xee, 0x18, 0x04, 0xa6,
-		0x2f, 0x76, 0x7a, 0xff, 0xe8, 0x23, 0x46, 0xf8, 0xe3, 0xc7, 0xf0, 0x69,
-		0x23, 0x64, 0x68, 0x52, 0x12, 0x64, 0x8a, 0xaa, 0xfa, 0x25, 0x3f, 0x6f,
-		0xf1, 0xca, 0xdf, 0x3b, 0x7c, 0x1e, 0x08, 0xc0, 0xf2, 0xbe, 0xfa, 0x37,
-		0x00, 0x00, 0xff, 0xff, 0x0f, 0xd6, 0xd9, 0x75, 0xd8, 0x15, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x58,
+		0xdd, 0x6e, 0xe3, 0xba, 0x11, 0xbe, 0xf7, 0x53, 0x10, 0x59, 0x14, 0x68,
+		0x01, 0x4b, 0x90, 0x15, 0xc7, 0x76, 0x14, 0xb4, 0xc0, 0xc6, 0xc9, 0xa2,
+		0x57, 0x7d, 0x81, 0xa2, 0x17, 0x94, 0x38, 0xb6, 0x88, 0xd0, 0xa2, 0x4a,
+		0x52, 0xb1, 0x7d, 0x8c, 0x7d, 0xf7, 0x03, 0x4a, 0xd4, 0x0f, 0x45, 0xca,
+		0xb1, 0x17, 0x38, 0xd8, 0x10, 0x01, 0x2c, 0x69, 0xbe, 0x99, 0xe1, 0xfc,
+		0x93, 0x29, 0x27, 0x67, 0x74, 0x99, 0x21, 0x74, 0xc0, 0x62, 0x4f, 0x8b,
+		0x04, 0x45, 0x2f, 0x33, 0x84, 0x52, 0x9c, 0x7d, 0xec, 0x05, 0xaf, 0x0a,
+		0x12, 0x64, 0x9c, 0x71, 0x91, 0xa0, 0x6f, 0x3f, 0xea, 0x3f, 0xfd, 0x71,
+		0xc7, 0x0b, 0x15, 0xec, 0xf0, 0x81, 0xb2, 0x73, 0x82, 0x1e, 0xfe, 0x0d,
+		0xec, 0x13, 0x14, 0xcd, 0x30, 0xfa, 0x0f, 0x54, 0xf0, 0x30, 0xef, 0x9e,
+		0xe7, 0xdf, 0x05, 0xc5, 0x6c, 0x2e, 0x71, 0x21, 0x03, 0x09, 0x82, 0xee,
+		0x5e, 0x66, 0x3f, 0x67, 0xf9, 0x02, 0x5d, 0x5a, 0x16, 0x92, 0xfe, 0x01,
+		0x09, 0x5a, 0x84, 0x2b, 0x01, 0x87, 0x97, 0xb1, 0x06, 0xcd, 0x43, 0x90,
+		0x72, 0xa5, 0xf8, 0x21, 0x41, 0x51, 0xb8, 0x31, 0x54, 0x35, 0xf4, 0x08,
+		0x74, 0x9f, 0xab, 0x04, 0x15, 0x5c, 0x1c, 0x30, 0xab, 0x19, 0xc7, 0x0e,
+		0xe3, 0x4d, 0x79, 0xd2, 0x80, 0x76, 0x03, 0xcf, 0xf5, 0x9f, 0x25, 0x08,
+		0x45, 0x68, 0x11, 0x95, 0x27, 0x2d, 0xf1, 0xe7, 0xac, 0x62, 0x35, 0x07,
+		0x46, 0xa5, 0x0a, 0xa4, 0x3a, 0x33, 0x08, 0xd4, 0xb9, 0x04, 0x2d, 0xa3,
+		0x00, 0x47, 0xbd, 0x12, 0x13, 0x42, 0x8b, 0x7d, 0xd2, 0x40, 0x0b, 0xfc,
+		0x59, 0x63, 0x4b, 0x2e, 0xa9, 0xa2, 0xbc, 0x48, 0x10, 0x4e, 0x25, 0x67,
+		0x95, 0xaa, 0x81, 0x8a, 0x97, 0x06, 0xc5, 0x60, 0xa7, 0x5a, 0x0b, 0xb7,
+		0x1b, 0x9b, 0x30, 0x77, 0xfc, 0x14, 0x6f, 0xe2, 0xb5, 0xfe, 0x78, 0xa4,
+		0x44, 0xe5, 0x09, 0x5a, 0x9a, 0xed, 0x74, 0x92, 0xc7, 0xfb, 0xc3, 0x6b,
+		0x9c, 0xa6, 0x0b, 0xfd, 0x86, 0x7f, 0x82, 0xd8, 0x31, 0x7e, 0x4c, 0x50,
+		0x4e, 0x09, 0x81, 0xa2, 0x55, 0x31, 0x54, 0xbc, 0x9c, 0x37, 0xbf, 0x1a,
+		0xf1, 0x57, 0x94, 0x1e, 0xa8, 0x7a, 0x5d, 0x81, 0x9e, 0x35, 0xba, 0x58,
+		0x9b, 0xcd, 0x8d, 0x8f, 0x56, 0xd1, 0x80, 0x6c, 0x20, 0xd7, 0xb2, 0x40,
+		0x4b, 0xbc, 0x8c, 0x46, 0x3c, 0xf1, 0xd8, 0xad, 0xf1, 0xb2, 0x51, 0xc3,
+		0x8a, 0x83, 0x94, 0x33, 0xe2, 0xf8, 0x48, 0xc1, 0x49, 0x05, 0x98, 0xd1,
+		0x7d, 0x91, 0xa0, 0x0c, 0x0a, 0x05, 0x62, 0x68, 0xae, 0xa8, 0xfe, 0xeb,
+		0xe8, 0x08, 0x64, 0x5c, 0xe0, 0xc6, 0x0e, 0xad, 0xc7, 0x79, 0x89, 0x33,
+		0xaa, 0xce, 0x3a, 0xfa, 0x96, 0xfa, 0x99, 0x50, 0x59, 0x32, 0x7c, 0x4e,
+		0x50, 0xca, 0x78, 0xf6, 0xa1, 0xd5, 0x0c, 0x25, 0x55, 0x10, 0xb4, 0xee,
+		0x37, 0x31, 0x5b, 0x9b, 0x60, 0x19, 0x5b, 0xd6, 0x0a, 0x1a, 0x7b, 0x2e,
+		0x46, 0x6f, 0x45, 0xa3, 0x7e, 0xf3, 0x7a, 0xc8, 0xce, 0xdd, 0xf6, 0xca,
+		0xf6, 0xf6, 0xd3, 0x42, 0xaf, 0x2b, 0xea, 0x5b, 0xdc, 0x42, 0x9c, 0x29,
+		0xfa, 0x09, 0xe8, 0x32, 0xe0, 0x10, 0x6f, 0xd7, 0x4f, 0x9b, 0xd5, 0x58,
+		0xec, 0xbf, 0x90, 0x2c, 0x71, 0x31, 0xdc, 0x8e, 0xd1, 0x71, 0xd9, 0xa8,
+		0x78, 0xc0, 0xd4, 0xfa, 0xda, 0xec, 0x6b, 0x63, 0x9c, 0xd2, 0x46, 0x5e,
+		0x70, 0x4e, 0x10, 0xae, 0x14, 0x7f, 0xb9, 0x21, 0x29, 0xac, 0x38, 0x18,
+		0x84, 0x9d, 0x91, 0xfb, 0x55, 0x3d, 0x32, 0x2a, 0x75, 0x01, 0x68, 0xe9,
+		0xd5, 0xe6, 0x48, 0x1b, 0x5e, 0xf1, 0xd2, 0xb2, 0x7f, 0x52, 0xa7, 0xff,
+		0x97, 0x05, 0xaf, 0xf3, 0x7b, 0x6b, 0x59, 0x23, 0x91, 0x2a, 0x06, 0xc6,
+		0x53, 0x2d, 0xe8, 0xed, 0x51, 0xaf, 0xab, 0x6e, 0x69, 0xc0, 0x19, 0x2f,
+		0x14, 0x14, 0x0a, 0x5d, 0x2c, 0x6d, 0x36, 0xe5, 0x09, 0x3d, 0x9a, 0x14,
+		0x50, 0x38, 0x65, 0x8d, 0xc7, 0x4c, 0x02, 0x2e, 0xa2, 0xe8, 0x6f, 0x35,
+		0x67, 0xfd, 0x21, 0x60, 0xf8, 0xcc, 0x2b, 0x95, 0xa0, 0x1d, 0x3d, 0x01,
+		0xe9, 0xe9, 0x55, 0x6e, 0xe9, 0xb3, 0x7a, 0xd4, 0xeb, 0x4a, 0xdd, 0x6c,
+		0x51, 0x73, 0x64, 0x7e, 0x11, 0x74, 0x69, 0xb5, 0x37, 0xb9, 0xa3, 0x4d,
+		0x69, 0x19, 0x6d, 0x55, 0x9e, 0xba, 0xff, 0x68, 0xc0, 0xa4, 0xee, 0x25,
+		0x4a, 0x24, 0x85, 0xca, 0x83, 0x2c, 0xa7, 0x8c, 0xfc, 0x9d, 0x13, 0xf2,
+		0x0f, 0x74, 0x99, 0xb0, 0xef, 0x56, 0xaf, 0x1e, 0x6e, 0x1b, 0xb2, 0x4d,
+		0xcf, 0x9f, 0x33, 0xc2, 0xac, 0xfe, 0xf4, 0xa4, 0x85, 0xea, 0x65, 0xbe,
+		0x91, 0xc6, 0x86, 0xe3, 0xdc, 0x1c, 0xd5, 0x82, 0x1d, 0xe3, 0x58, 0xf5,
+		0x5b, 0xb1, 0x43, 0xbb, 0x0d, 0x82, 0x71, 0xa7, 0x68, 0xf8, 0x13, 0xbb,
+		0x3d, 0xd6, 0x2b, 0x8e, 0x47, 0xf1, 0x7e, 0xea, 0x6b, 0xad, 0x31, 0x5e,
+		0x5f, 0x84, 0x81, 0x31, 0x5a, 0x4a, 0x2a, 0xc7, 0x35, 0xa9, 0x96, 0xae,
+		0xa5, 0xcc, 0x42, 0x82, 0x65, 0x9e, 0x72, 0x2c, 0x08, 0x0a, 0x0b, 0x38,
+		0xd6, 0x02, 0xdd, 0x22, 0xee, 0x34, 0xc5, 0x36, 0x52, 0xc6, 0x70, 0x3c,
+		0x61, 0x91, 0x91, 0x11, 0x52, 0x2e, 0x08, 0x88, 0xbe, 0xdc, 0x75, 0xfe,
+		0xd5, 0x51, 0xd8, 0x56, 0xaa, 0x86, 0x28, 0x10, 0x98, 0xd0, 0x4a, 0x26,
+		0x28, 0x1e, 0x55, 0xa1, 0xed, 0x5b, 0xbc, 0x7d, 0x9b, 0x6a, 0x61, 0xa6,
+		0xc0, 0x5c, 0x2b, 0x51, 0xbd, 0xe6, 0x18, 0x5d, 0x6e, 0xa4, 0x0c, 0xa5,
+		0xc2, 0xaa, 0x92, 0x73, 0xeb, 0x5d, 0x29, 0x78, 0x06, 0x52, 0xd2, 0x62,
+		0x6f, 0xbf, 0x3f, 0x62, 0xaa, 0x9c, 0x97, 0x32, 0xcb, 0x81, 0x54, 0x0c,
+		0x88, 0x9d, 0x5e, 0x75, 0x76, 0x1d, 0x68, 0x11, 0xf4, 0x09, 0xe7, 0x1a,
+		0x38, 0xe7, 0x52, 0x49, 0xb7, 0xdc, 0x7b, 0x28, 0x25, 0x64, 0x7a, 0x0f,
+		0x01, 0xc3, 0x29, 0x30, 0x74, 0xe9, 0x8d, 0xd9, 0xfa, 0x2f, 0x2e, 0x4f,
+		0x48, 0x72, 0x46, 0x09, 0xfa, 0xf6, 0xbe, 0xd5, 0xeb, 0xc5, 0xae, 0xf9,
+		0x51, 0xf8, 0x6c, 0x8d, 0x46, 0x1d, 0x70, 0xb1, 0xb2, 0xdb, 0x48, 0xfb,
+		0x7e, 0xe9, 0x89, 0x07, 0x1e, 0xfc, 0xbf, 0x82, 0x0a, 0x24, 0xba, 0x4c,
+		0xb6, 0xc4, 0xe1, 0x46, 0xe2, 0x28, 0xba, 0xc6, 0x84, 0x8e, 0xfb, 0xd2,
+		0x72, 0x94, 0x3b, 0xef, 0x4f, 0x7a, 0x4d, 0x33, 0x28, 0xed, 0x72, 0xf9,
+		0xaa, 0xd7, 0x68, 0xdb, 0x6d, 0x72, 0x0d, 0xd5, 0x5a, 0x98, 0xad, 0xcd,
+		0xc2, 0x4c, 0x00, 0x56, 0xd0, 0xb0, 0x43, 0x3b, 0x2e, 0x0e, 0x8e, 0x2f,
+		0xe2, 0x76, 0x07, 0x16, 0x69, 0x48, 0x8b, 0xb2, 0x52, 0x43, 0xe2, 0xce,
+		0x9a, 0xcb, 0x2b, 0xf4, 0x3d, 0xaa, 0x0d, 0x8a, 0xd5, 0x15, 0xf6, 0x21,
+		0x25, 0x2e, 0x62, 0x19, 0x45, 0x5f, 0x09, 0xf8, 0xaf, 0x1e, 0x35, 0xff,
+		0x99, 0xe5, 0x90, 0x7d, 0xa4, 0xfc, 0xf4, 0xbf, 0x21, 0xba, 0x69, 0xa3,
+		0x13, 0xe2, 0x32, 0x7e, 0x38, 0xe0, 0x82, 0xc8, 0xda, 0xb1, 0x58, 0x00,
+		0x9e, 0xfb, 0xe9, 0xe0, 0x04, 0x59, 0xa5, 0xb8, 0x08, 0x78, 0xa9, 0x43,
+		0xb2, 0xa7, 0xf7, 0xe8, 0xe9, 0x4e, 0x6e, 0x36, 0xcb, 0x3a, 0x9c, 0xc7,
+		0x72, 0x6a, 0x31, 0xe3, 0x97, 0x12, 0x18, 0x64, 0xce, 0x5b, 0x4b, 0xb4,
+		0x67, 0xa4, 0x72, 0x85, 0xa1, 0xcb, 0x54, 0x5e, 0x74, 0x6d, 0xee, 0x87,
+		0x5e, 0x5d, 0xa9, 0x51, 0x02, 0x17, 0x52, 0xc7, 0x46, 0x82, 0xaa, 0xb2,
+		0x04, 0x91, 0x61, 0x09, 0x9e, 0x34, 0xf2, 0xfa, 0xfd, 0x57, 0x77, 0xe2,
+		0xe3, 0xdd, 0xd7, 0xd8, 0x45, 0x9f, 0xea, 0x6f, 0xdf, 0xf5, 0xb2, 0x0a,
+		0xee, 0x52, 0x17, 0x5c, 0xaf, 0xb1, 0x1b, 0xc1, 0x56, 0xfc, 0x6d, 0xe2,
+		0x2f, 0x47, 0x96, 0x31, 0x97, 0xd2, 0x3d, 0xe1, 0xb5, 0x90, 0xef, 0x1b,
+		0xbd, 0x9c, 0xc2, 0xd3, 0x9c, 0xb6, 0x9c, 0xa8, 0x4b, 0x2b, 0xa5, 0x74,
+		0xf8, 0x4c, 0x96, 0x3f, 0x8b, 0xbc, 0xa1, 0x1e, 0x54, 0xbe, 0x89, 0x5e,
+		0xd3, 0xce, 0xb6, 0x7f, 0x65, 0xaf, 0x71, 0x7a, 0xfe, 0x72, 0x5a, 0xe3,
+		0x30, 0xc3, 0x45, 0xd6, 0x96, 0x6c, 0x8f, 0x95, 0xd7, 0x7a, 0x0d, 0x55,
+		0xdb, 0x6e, 0xf4, 0x6a, 0xea, 0xd3, 0x27, 0x85, 0x63, 0x6b, 0x2e, 0xd3,
+		0x01, 0xbc, 0xf1, 0xb1, 0x32, 0xf2, 0x2d, 0xfa, 0x2a, 0x0d, 0xae, 0x60,
+		0x16, 0x91, 0x0f, 0xf3, 0xdb, 0xba, 0x8c, 0xad, 0x05, 0x14, 0xce, 0x91,
+		0x25, 0xb2, 0xea, 0x78, 0xa7, 0xcf, 0xa8, 0x63, 0xac, 0xde, 0xf5, 0xf2,
+		0x73, 0xec, 0xce, 0x22, 0x03, 0xb6, 0x8f, 0x3e, 0xcb, 0xe5, 0xc0, 0x08,
+		0xba, 0x7c, 0x2d, 0x6c, 0x1b, 0x3d, 0x3e, 0xc7, 0xaf, 0x0e, 0xbc, 0xc4,
+		0x95, 0x84, 0x69, 0xbc, 0x97, 0x3a, 0x04, 0x42, 0x95, 0xe7, 0xc8, 0x31,
+		0x8d, 0x98, 0x38, 0x59, 0x2d, 0x6e, 0xd3, 0x51, 0xd2, 0x7d, 0x41, 0x8b,
+		0xbd, 0x37, 0x30, 0x7c, 0x26, 0xc9, 0x72, 0x2c, 0x54, 0x70, 0x14, 0x58,
+		0x57, 0xc0, 0x5f, 0x44, 0x4d, 0x46, 0x97, 0x93, 0x56, 0xd7, 0x82, 0xa3,
+		0xe6, 0x39, 0x31, 0xd4, 0x7a, 0x49, 0xc3, 0x46, 0x9c, 0x12, 0x93, 0x29,
+		0xd8, 0x17, 0xba, 0x69, 0x34, 0x99, 0x1c, 0x79, 0x46, 0xb0, 0x14, 0x0b,
+		0xcb, 0x4e, 0x3e, 0x81, 0x4b, 0xbd, 0xac, 0xa3, 0xa4, 0xc9, 0x0e, 0xeb,
+		0x70, 0xe6, 0x32, 0x46, 0x17, 0x77, 0xf0, 0x1e, 0xb3, 0x98, 0x68, 0x1d,
+		0x1e, 0x2d, 0x9e, 0xf4, 0xf2, 0xea, 0xaf, 0x79, 0x4f, 0x29, 0xff, 0xb8,
+		0x7e, 0x8f, 0x9f, 0x5f, 0xbd, 0xb0, 0x3a, 0x04, 0xa7, 0x70, 0x4f, 0xf1,
+		0xea, 0xc7, 0x7a, 0x7b, 0xdd, 0xca, 0xc3, 0x69, 0xee, 0xf5, 0x5d, 0xaf,
+		0xeb, 0xf4, 0x76, 0x46, 0x8f, 0xcf, 0xa3, 0xde, 0x4b, 0x87, 0xbb, 0x04,
+		0xf4, 0x86, 0xb0, 0x8c, 0x7e, 0x0d, 0xd1, 0xdb, 0xc0, 0x40, 0xba, 0x83,
+		0x99, 0x8d, 0xe1, 0xc5, 0x8e, 0xee, 0x91, 0xf7, 0x98, 0x3d, 0xa5, 0x98,
+		0x05, 0xb9, 0x3b, 0x9a, 0x2d, 0xf4, 0xd4, 0xb9, 0x7c, 0xec, 0x54, 0x01,
+		0xf8, 0x03, 0x84, 0xbc, 0x4b, 0xcf, 0x31, 0xe8, 0x5e, 0x4d, 0xc7, 0xf8,
+		0x7b, 0x75, 0x6d, 0x7f, 0x05, 0xbc, 0x34, 0x6d, 0xe4, 0x8b, 0x5a, 0xe8,
+		0x22, 0x73, 0xcc, 0x76, 0x2e, 0xfc, 0x7d, 0xb5, 0x7e, 0x8f, 0x63, 0x07,
+		0x5e, 0x82, 0xd0, 0xd3, 0xa1, 0xee, 0xf2, 0x77, 0xd9, 0xc9, 0x83, 0xbb,
+		0xd7, 0x54, 0x1e, 0x16, 0x37, 0x5a, 0xcb, 0x8b, 0x0c, 0x3f, 0x31, 0xab,
+		0xc0, 0xe9, 0xbc, 0xf6, 0x09, 0xca, 0x24, 0x53, 0xec, 0x29, 0xcd, 0x0a,
+		0xa7, 0xf2, 0xe6, 0xa1, 0xe1, 0x96, 0xfe, 0x51, 0x33, 0x9c, 0xba, 0x80,
+		0xe8, 0xaf, 0x02, 0x05, 0x30, 0xac, 0x6f, 0x24, 0x3d, 0xd7, 0x12, 0x93,
+		0x03, 0xdc, 0xe0, 0x6a, 0xc8, 0xb3, 0x39, 0x53, 0x4b, 0xaf, 0xce, 0xff,
+		0xde, 0xa9, 0xe7, 0x96, 0xf1, 0xa6, 0xd9, 0x95, 0xb9, 0x45, 0x4d, 0xf0,
+		0x4e, 0x39, 0x9d, 0xc2, 0x1e, 0x50, 0x0d, 0xab, 0xa0, 0x1b, 0x61, 0xeb,
+		0xcb, 0xbe, 0x04, 0x3d, 0x3c, 0xbc, 0x78, 0x0d, 0xd3, 0x75, 0x84, 0x86,
+		0x7e, 0x70, 0x0b, 0xea, 0xbf, 0x3d, 0xed, 0xef, 0x46, 0x7d, 0x8a, 0x86,
+		0x19, 0x03, 0xd3, 0x79, 0xea, 0x5f, 0x89, 0x56, 0x28, 0x1f, 0xd3, 0xf6,
+		0xf7, 0x8b, 0x1e, 0xb3, 0x38, 0x6c, 0xe5, 0x87, 0x44, 0x2a, 0x0f, 0x0f,
+		0xa0, 0x72, 0x4e, 0xe6, 0x13, 0x5f, 0x4b, 0x41, 0xb9, 0xa0, 0xea, 0x3c,
+		0x3c, 0xaf, 0xac, 0x23, 0xaf, 0x3d, 0x0d, 0x42, 0x54, 0xc5, 0x90, 0xf8,
+		0x39, 0x9a, 0x32, 0xbe, 0xa6, 0x0c, 0x30, 0x63, 0x9e, 0x3a, 0x3d, 0x35,
+		0x83, 0x18, 0x2e, 0x5a, 0xd6, 0x1d, 0xa3, 0xb8, 0x45, 0xfe, 0xbb, 0xa6,
+		0xea, 0x5a, 0x89, 0x9b, 0x1c, 0xd4, 0xa8, 0x5b, 0x0a, 0x5e, 0x82, 0x50,
+		0x14, 0x64, 0x5b, 0xc9, 0xda, 0xb1, 0x64, 0x19, 0x39, 0x7c, 0x4b, 0x01,
+		0xee, 0x79, 0xf0, 0x98, 0x53, 0x05, 0x81, 0x2c, 0x71, 0x06, 0x89, 0xa6,
+		0xa8, 0x07, 0xa2, 0xfa, 0x03, 0x17, 0xa4, 0x7e, 0x48, 0x50, 0x5d, 0x6b,
+		0x03, 0xfd, 0x62, 0x2c, 0x1f, 0x0e, 0xa5, 0xf1, 0xba, 0x5b, 0x41, 0xff,
+		0x1c, 0x00, 0xb0, 0x8b, 0xca, 0x59, 0x62, 0x1c, 0x00, 0x00,
 	},
 		"static/style.css",
 	)
@@ -29423,38 +29447,52 @@ func static_style_css() ([]byte, error) {
 
 func template_dashboard_html() ([]byte, error) {
 	return bindata_read([]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x00, 0xff, 0x7c, 0x53,
-		0xb1, 0x6e, 0xc3, 0x20, 0x10, 0xdd, 0xf3, 0x15, 0x88, 0x3d, 0xb1, 0xb2,
-		0x3b, 0x2c, 0xcd, 0x92, 0xa5, 0x6a, 0x9b, 0x48, 0x9d, 0x2f, 0xe6, 0x1c,
-		0x50, 0x5d, 0x9c, 0x1a, 0xdc, 0xa8, 0x42, 0xfe, 0xf7, 0x02, 0x0e, 0x86,
-		0x5a, 0xa9, 0x27, 0x73, 0xf7, 0xde, 0x3b, 0x8e, 0x77, 0x67, 0x6b, 0x39,
-		0xd6, 0x52, 0x21, 0xa1, 0x9f, 0x20, 0x15, 0x1d, 0x86, 0x55, 0xc9, 0xe5,
-		0x37, 0xa9, 0x1a, 0xd0, 0x7a, 0x47, 0xab, 0x56, 0x19, 0x54, 0x86, 0x70,
-		0xd0, 0xe2, 0xdc, 0x42, 0xc7, 0x29, 0x5b, 0x11, 0x52, 0x8a, 0x6d, 0x24,
-		0x18, 0x69, 0x1a, 0xa4, 0xac, 0x04, 0x22, 0x3a, 0xac, 0x77, 0xb4, 0xa0,
-		0xcc, 0xda, 0xcd, 0xc9, 0x67, 0x87, 0xa1, 0x2c, 0x80, 0x95, 0x85, 0xd8,
-		0x06, 0x4d, 0x56, 0x55, 0xe1, 0x2d, 0xd4, 0x71, 0xd9, 0x49, 0xf7, 0xd5,
-		0x63, 0x8f, 0x45, 0x40, 0x9e, 0x3a, 0x04, 0x83, 0xe4, 0xd5, 0x67, 0x7c,
-		0x09, 0xaf, 0x2e, 0x9c, 0x3c, 0x96, 0x19, 0xa5, 0xd6, 0xca, 0x9a, 0x6c,
-		0xde, 0x50, 0xf7, 0x8d, 0x71, 0x5d, 0x87, 0x6a, 0x06, 0xce, 0x0d, 0x8e,
-		0xb0, 0x8f, 0x04, 0x02, 0x8f, 0x91, 0x8f, 0xbb, 0x14, 0x04, 0x38, 0xf6,
-		0x23, 0xdd, 0xb3, 0xc2, 0x6d, 0xe4, 0xb0, 0x2f, 0x0b, 0x23, 0xfe, 0xa1,
-		0x69, 0x03, 0xa6, 0xd7, 0x94, 0x1d, 0xc3, 0x77, 0x81, 0x78, 0xed, 0xda,
-		0x0a, 0xb5, 0x96, 0xea, 0x42, 0xd9, 0xcb, 0x74, 0x5e, 0x10, 0xdc, 0x40,
-		0x9a, 0xc0, 0x7e, 0x1f, 0x0f, 0x4b, 0x4d, 0x54, 0x02, 0x79, 0xdf, 0xa0,
-		0x6b, 0xf9, 0x18, 0x8f, 0x7f, 0xe9, 0x2e, 0x9a, 0x1e, 0xea, 0x91, 0xcc,
-		0x84, 0xd2, 0x9c, 0x5b, 0xfe, 0x93, 0xa8, 0xd6, 0x76, 0xa0, 0x2e, 0x38,
-		0xb3, 0xf1, 0xa1, 0x59, 0x9c, 0xcd, 0x67, 0xe5, 0xe6, 0x7c, 0xd8, 0x0f,
-		0x43, 0x18, 0xb8, 0x3f, 0x8c, 0xd3, 0x36, 0x7c, 0xae, 0x73, 0xf0, 0xe8,
-		0x98, 0xa7, 0x3c, 0x84, 0x4f, 0xa0, 0x3f, 0x74, 0x32, 0x6a, 0x99, 0x77,
-		0xb7, 0x68, 0x99, 0x34, 0x59, 0x33, 0xa7, 0xe5, 0xe6, 0xf8, 0xf7, 0xa3,
-		0xe2, 0xd3, 0xab, 0x1d, 0x96, 0xec, 0x71, 0x41, 0x5a, 0x26, 0xc7, 0x6b,
-		0x34, 0x26, 0x62, 0xbe, 0xcb, 0xed, 0x3a, 0xd8, 0xa1, 0x69, 0x76, 0x89,
-		0x8c, 0x68, 0x0d, 0xa4, 0x86, 0xb5, 0xe0, 0x7c, 0xdd, 0xba, 0x9f, 0xa4,
-		0x90, 0x19, 0xe7, 0xca, 0x9e, 0x5b, 0x32, 0x4a, 0x49, 0x15, 0x36, 0xde,
-		0xcd, 0xf1, 0x9a, 0x06, 0x97, 0x2d, 0x7a, 0x6c, 0xf2, 0x9e, 0xbc, 0x7f,
-		0x62, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0x39, 0x73, 0xb7, 0xd1, 0xc2,
-		0x03, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x53,
+		0x4d, 0x6f, 0xdb, 0x30, 0x0c, 0xbd, 0xf7, 0x57, 0x08, 0x3a, 0x6d, 0x87,
+		0x58, 0xe8, 0x5d, 0xd5, 0xa5, 0xc5, 0x50, 0x03, 0xcd, 0xbe, 0x12, 0x60,
+		0x67, 0xc5, 0xa2, 0x63, 0x61, 0xae, 0x9c, 0x59, 0x74, 0xdb, 0x41, 0xf0,
+		0x7f, 0x1f, 0x24, 0x5b, 0xb6, 0x92, 0x25, 0x46, 0xb0, 0xf5, 0x14, 0x92,
+		0x7a, 0x7c, 0xa1, 0x1f, 0xf9, 0x9c, 0x53, 0x50, 0x6a, 0x03, 0x84, 0x3e,
+		0x4b, 0x6d, 0x68, 0xdf, 0xdf, 0x70, 0xa5, 0x5f, 0x48, 0x51, 0x4b, 0x6b,
+		0xef, 0x68, 0xd1, 0x18, 0x04, 0x83, 0x44, 0x49, 0x5b, 0xed, 0x1a, 0xd9,
+		0x2a, 0x2a, 0x6e, 0x08, 0xe1, 0xd5, 0x6d, 0x04, 0xa0, 0xc6, 0x1a, 0xa8,
+		0xe0, 0x92, 0x54, 0x2d, 0x94, 0x77, 0x94, 0x51, 0xe1, 0x5c, 0xb6, 0xf5,
+		0xd5, 0xbe, 0xe7, 0x4c, 0x0a, 0xce, 0xaa, 0xdb, 0xd0, 0x93, 0xb0, 0x1a,
+		0x78, 0x0d, 0x3c, 0x84, 0xcc, 0x7d, 0xbf, 0x3a, 0xe8, 0x80, 0x85, 0x97,
+		0xfb, 0x16, 0x24, 0x02, 0xf9, 0xe6, 0x2b, 0x9e, 0xc2, 0x77, 0x33, 0xa5,
+		0x5f, 0x22, 0xcd, 0xd0, 0xea, 0x9c, 0x2e, 0x49, 0xf6, 0x1d, 0x6c, 0x57,
+		0x63, 0x16, 0xb0, 0xb6, 0xef, 0x07, 0x52, 0x94, 0xbb, 0x1a, 0x06, 0x94,
+		0xcf, 0x2a, 0x90, 0x2a, 0x66, 0x3e, 0x6f, 0xe7, 0x24, 0x3c, 0xc7, 0xb1,
+		0xb4, 0xa2, 0x22, 0x10, 0x91, 0xfc, 0x81, 0x33, 0xac, 0x2e, 0xc0, 0x2c,
+		0x4a, 0xec, 0x2c, 0x15, 0x9b, 0xf0, 0xbb, 0x00, 0x3c, 0xb4, 0x4d, 0x01,
+		0xd6, 0x6a, 0xb3, 0xa7, 0xe2, 0xeb, 0x14, 0x2f, 0x34, 0xbc, 0x4a, 0x8d,
+		0x01, 0xfd, 0x63, 0x08, 0x96, 0x86, 0x28, 0x2a, 0x50, 0x5d, 0x0d, 0x8a,
+		0x8a, 0x4d, 0x0c, 0x8f, 0xe1, 0x9c, 0xcd, 0x1f, 0xca, 0xd9, 0x91, 0x08,
+		0x1c, 0x77, 0x8d, 0xfa, 0x3d, 0x43, 0x9d, 0x6b, 0xa5, 0xd9, 0xc3, 0x79,
+		0x35, 0xcf, 0x6a, 0xa6, 0xc4, 0xe9, 0xe6, 0x9c, 0xcb, 0xf2, 0x87, 0xbe,
+		0xa7, 0x62, 0x0c, 0x86, 0xdd, 0xa3, 0x3a, 0xed, 0x73, 0x2e, 0x1b, 0x84,
+		0xeb, 0xfb, 0x0b, 0xcf, 0x5b, 0x69, 0x7f, 0xda, 0x59, 0xaf, 0x65, 0xdc,
+		0xa8, 0xd4, 0x32, 0x68, 0x52, 0xe8, 0x14, 0x96, 0x6a, 0x44, 0x88, 0x73,
+		0x60, 0xd4, 0xf4, 0xd5, 0x9c, 0x25, 0x2a, 0x71, 0x96, 0xdc, 0x94, 0x73,
+		0x50, 0x5b, 0x98, 0x81, 0xe9, 0x65, 0x37, 0xab, 0x20, 0x87, 0xa5, 0xc9,
+		0x9f, 0xe8, 0xf8, 0x5a, 0x4a, 0x52, 0xca, 0x55, 0xa5, 0xd4, 0xaa, 0xa1,
+		0x82, 0x33, 0x9d, 0x60, 0x0e, 0xe2, 0x73, 0x43, 0x86, 0x56, 0x52, 0x84,
+		0xfb, 0x57, 0x9c, 0x1d, 0xe6, 0xfd, 0x25, 0x67, 0x1f, 0x87, 0x9c, 0x8a,
+		0x47, 0x4e, 0x78, 0x6c, 0x2c, 0x0e, 0xab, 0x4b, 0xe7, 0xaa, 0x7c, 0x35,
+		0x7a, 0x2e, 0xa9, 0x5b, 0x28, 0x50, 0x37, 0x66, 0x55, 0xcb, 0x1d, 0xd4,
+		0x54, 0x3c, 0x7e, 0xd9, 0x6c, 0xc9, 0x53, 0xbe, 0xce, 0xb7, 0x9b, 0xe4,
+		0x2f, 0xff, 0xc7, 0x4f, 0x7e, 0x9c, 0x2b, 0x2d, 0x92, 0x1b, 0x52, 0xd6,
+		0x7a, 0x5f, 0xe1, 0x3b, 0x39, 0x64, 0xb4, 0xe9, 0x5a, 0xbe, 0x91, 0xa2,
+		0x31, 0x45, 0xd7, 0xb6, 0x60, 0xf0, 0xca, 0x86, 0x56, 0x22, 0xbc, 0x9b,
+		0x9d, 0xe6, 0x95, 0x5c, 0x74, 0x93, 0x73, 0x01, 0x75, 0xf1, 0x8c, 0x73,
+		0xf3, 0x29, 0x48, 0x73, 0x11, 0x30, 0xf9, 0x60, 0x3c, 0x06, 0x89, 0xf0,
+		0xa4, 0x9f, 0x35, 0x66, 0xdb, 0xaa, 0x6d, 0x10, 0xc3, 0xed, 0x93, 0x0f,
+		0x18, 0x93, 0x8f, 0xe3, 0x19, 0x9d, 0x67, 0xf3, 0x0c, 0x6b, 0xf9, 0x76,
+		0x3f, 0xa9, 0xe6, 0x69, 0xff, 0xae, 0x0c, 0x2e, 0x58, 0x5d, 0x43, 0xe5,
+		0xe7, 0x89, 0x24, 0x43, 0xcc, 0xed, 0x41, 0x9a, 0x28, 0x7d, 0x67, 0x34,
+		0x52, 0xc1, 0x2c, 0x67, 0xbe, 0x2a, 0x96, 0xa9, 0xff, 0xc5, 0xb4, 0x89,
+		0x57, 0x06, 0xf8, 0x58, 0x88, 0xe9, 0x9f, 0x01, 0x00, 0x62, 0xa6, 0x50,
+		0x24, 0x06, 0x07, 0x00, 0x00,
 	},
 		"template/dashboard.html",
 	)
@@ -29502,139 +29540,641 @@ func template_layout_html() ([]byte, error) {
 
 func template_queue_create_html() ([]byte, error) {
 	return bindata_read([]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x00, 0xff, 0x9c, 0x55,
-		0xb1, 0x6e, 0xdb, 0x30, 0x14, 0xdc, 0xf3, 0x15, 0x0f, 0x5c, 0xb2, 0x54,
-		0x61, 0x83, 0x4e, 0x05, 0x64, 0x01, 0x45, 0xe6, 0x0e, 0x05, 0x32, 0xb5,
-		0x0d, 0x0c, 0x9a, 0x7a, 0xae, 0x09, 0x4b, 0xa4, 0x42, 0x3e, 0xc6, 0x6e,
-		0x05, 0xff, 0x7b, 0x9f, 0x28, 0x59, 0x91, 0x5d, 0x27, 0x90, 0x3b, 0x59,
-		0xba, 0x77, 0x3c, 0xde, 0x9d, 0x08, 0xba, 0x6d, 0x4b, 0x5c, 0x1b, 0x8b,
-		0x20, 0x6a, 0x65, 0xac, 0x38, 0x1c, 0x6e, 0xf2, 0xd2, 0xbc, 0x80, 0xae,
-		0x54, 0x08, 0x0b, 0xa1, 0x9d, 0x25, 0xb4, 0x04, 0xda, 0xa3, 0x22, 0xcc,
-		0x9e, 0x23, 0x46, 0x14, 0xc5, 0x0d, 0x40, 0xbe, 0xb9, 0x3f, 0x72, 0xc8,
-		0x50, 0xc5, 0x60, 0xae, 0x60, 0xe3, 0x71, 0xbd, 0x10, 0x32, 0xb1, 0xa4,
-		0xc5, 0x9d, 0x28, 0xda, 0xf6, 0xee, 0xb1, 0x1b, 0x1f, 0x0e, 0xb9, 0x54,
-		0x45, 0x2e, 0x37, 0xf7, 0x69, 0xf1, 0xda, 0xf9, 0x1a, 0x4c, 0xb9, 0x10,
-		0x89, 0x9a, 0x0d, 0xea, 0x1d, 0x2a, 0x8e, 0xaa, 0x17, 0x26, 0x4a, 0x93,
-		0x71, 0xf6, 0x64, 0x03, 0xa8, 0x91, 0x36, 0x8e, 0x85, 0x1a, 0x17, 0x28,
-		0x19, 0x63, 0xf5, 0x89, 0x7f, 0x63, 0x9b, 0x48, 0xbc, 0xd3, 0x30, 0xe2,
-		0x61, 0xa5, 0x56, 0x58, 0x15, 0xdf, 0x3a, 0x05, 0xb0, 0xaa, 0xc6, 0x5c,
-		0xf6, 0xc8, 0x71, 0xde, 0xaf, 0xa0, 0xdf, 0x0d, 0x72, 0x32, 0xdc, 0x93,
-		0x48, 0xac, 0xc1, 0xcf, 0x92, 0x95, 0x92, 0xef, 0xee, 0xb7, 0xa9, 0x94,
-		0xc6, 0x8d, 0xab, 0x4a, 0xf4, 0x0b, 0x21, 0x20, 0xd5, 0xd0, 0x3d, 0xbc,
-		0xa8, 0x2a, 0xa6, 0x87, 0x46, 0x11, 0xa1, 0x67, 0xc3, 0x3f, 0x54, 0xf6,
-		0xe7, 0x4b, 0xf6, 0xfd, 0x63, 0xf6, 0xf9, 0x67, 0xf6, 0xd4, 0x7e, 0xfa,
-		0x70, 0x10, 0xe0, 0xf1, 0x39, 0x1a, 0x8f, 0x25, 0xa8, 0x48, 0x6e, 0xed,
-		0x74, 0x0c, 0x83, 0x79, 0xc9, 0xee, 0xdf, 0xca, 0xe1, 0xb9, 0x8b, 0xf3,
-		0x24, 0x5f, 0xd5, 0x3e, 0xe1, 0x32, 0xcc, 0x4d, 0x52, 0xab, 0xfd, 0x32,
-		0x29, 0xa5, 0x24, 0xfc, 0x96, 0xf5, 0x6f, 0x97, 0xf3, 0xb0, 0xbe, 0x89,
-		0x35, 0xd8, 0x58, 0xaf, 0xd0, 0x83, 0x5b, 0x03, 0xa9, 0xb0, 0x0d, 0xd0,
-		0x78, 0xa7, 0x31, 0x04, 0x0e, 0xd0, 0x30, 0x1c, 0x90, 0x0f, 0x4a, 0x39,
-		0x4d, 0xcc, 0x59, 0x9f, 0xda, 0xfb, 0x69, 0xd2, 0xd1, 0x57, 0x53, 0x5c,
-		0xa1, 0x99, 0xcb, 0x66, 0x46, 0x31, 0xcc, 0xd4, 0xd1, 0x7b, 0x3e, 0xa9,
-		0xe7, 0xf5, 0x3c, 0x8c, 0x13, 0xd8, 0x39, 0xbf, 0x45, 0x7f, 0x55, 0x4d,
-		0x13, 0xdd, 0xb1, 0xac, 0x29, 0xf6, 0x76, 0x65, 0xf5, 0x85, 0x7c, 0x3e,
-		0x5a, 0x6b, 0xec, 0x2f, 0x50, 0x04, 0xac, 0x81, 0x57, 0xd5, 0x35, 0x43,
-		0x6f, 0x5e, 0x55, 0xe4, 0x0d, 0x86, 0xf3, 0x96, 0x1e, 0x59, 0x0f, 0x38,
-		0x5c, 0x3f, 0x9d, 0xdb, 0x50, 0xe7, 0x62, 0xd9, 0xd5, 0xd4, 0x6b, 0xa6,
-		0x86, 0x3a, 0x2c, 0xeb, 0x6a, 0x1a, 0xb0, 0xcb, 0x0d, 0x9d, 0xee, 0x37,
-		0xa7, 0x88, 0xf7, 0x33, 0x99, 0x1a, 0x5d, 0xfc, 0xe7, 0xdb, 0xa7, 0x5d,
-		0x86, 0xd9, 0x55, 0x99, 0x8e, 0x7a, 0xaf, 0x89, 0x46, 0xe4, 0x9d, 0x3c,
-		0xaf, 0x9c, 0xff, 0x4d, 0xb3, 0x8a, 0x44, 0xce, 0x4e, 0xbe, 0x4e, 0x0f,
-		0x0c, 0x36, 0x43, 0x5c, 0xd5, 0x86, 0xc6, 0x0b, 0x72, 0x45, 0x56, 0x14,
-		0x0f, 0xe9, 0x7e, 0xcc, 0x65, 0x4f, 0xbc, 0xbc, 0x4e, 0x2b, 0x3e, 0x1c,
-		0xd5, 0x74, 0x1d, 0x1c, 0x21, 0x3e, 0x36, 0x95, 0xd1, 0xdb, 0x85, 0xd8,
-		0x19, 0x5b, 0xba, 0xdd, 0x5d, 0xe5, 0xb4, 0x4a, 0x77, 0xec, 0xad, 0xbc,
-		0x65, 0xf1, 0xc4, 0x3a, 0x15, 0x1f, 0x9d, 0xe7, 0xb2, 0xbb, 0x94, 0x8b,
-		0x9b, 0x01, 0x68, 0x5b, 0xb4, 0x25, 0xff, 0x79, 0xfc, 0x0d, 0x00, 0x00,
-		0xff, 0xff, 0x28, 0x59, 0x20, 0xd1, 0x4f, 0x06, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x9a,
+		0x6d, 0x8f, 0xdb, 0x36, 0x12, 0xc7, 0xdf, 0xe7, 0x53, 0x0c, 0xf4, 0xa6,
+		0xed, 0xd5, 0x5a, 0x7b, 0x37, 0x9b, 0xe0, 0x02, 0x78, 0x5d, 0xa4, 0x9b,
+		0xf6, 0x52, 0xa0, 0xb9, 0xe6, 0x92, 0x1e, 0x0a, 0x5c, 0x1b, 0x18, 0xb4,
+		0x38, 0x5e, 0xb1, 0xa6, 0x48, 0x85, 0xa4, 0xd6, 0x76, 0x0d, 0x7f, 0xf7,
+		0x03, 0x1f, 0x24, 0x4b, 0x96, 0x64, 0xcb, 0xdb, 0xbc, 0x59, 0xad, 0xf8,
+		0xf0, 0x27, 0xe7, 0xe7, 0xe1, 0x70, 0x48, 0x7b, 0xb7, 0xa3, 0xb8, 0x64,
+		0x02, 0x21, 0xca, 0x08, 0x13, 0xd1, 0x7e, 0xff, 0x6c, 0x4a, 0xd9, 0x23,
+		0x24, 0x9c, 0x68, 0x7d, 0x17, 0x25, 0x52, 0x18, 0x14, 0x06, 0x12, 0x85,
+		0xc4, 0x60, 0xfc, 0xb9, 0xc0, 0x02, 0xa3, 0xd9, 0x33, 0x80, 0x69, 0x7a,
+		0x5d, 0xb6, 0x31, 0xcc, 0x70, 0x8c, 0x66, 0x53, 0x02, 0xa9, 0xc2, 0xe5,
+		0x5d, 0x34, 0x76, 0xad, 0xc6, 0x02, 0xd7, 0xd1, 0x6c, 0xb7, 0xbb, 0xfa,
+		0xd5, 0x56, 0xef, 0xf7, 0xd3, 0x31, 0x99, 0x4d, 0xc7, 0xe9, 0xb5, 0xeb,
+		0xbc, 0x94, 0x2a, 0x03, 0x46, 0xef, 0x22, 0xd7, 0x34, 0x0e, 0xea, 0xb6,
+		0x34, 0x2a, 0x55, 0x3b, 0x6a, 0x48, 0x62, 0x98, 0x14, 0x8d, 0x01, 0x20,
+		0x43, 0x93, 0x4a, 0x7a, 0x17, 0xe5, 0x52, 0x1b, 0x37, 0x31, 0x80, 0xfa,
+		0xfc, 0x99, 0xc8, 0x0b, 0x03, 0x8c, 0x86, 0x2a, 0x80, 0x29, 0x27, 0x0b,
+		0xe4, 0xb3, 0xff, 0x58, 0x05, 0x10, 0x24, 0xc3, 0xe9, 0xd8, 0x97, 0x94,
+		0xf5, 0xbe, 0x87, 0xd9, 0xe6, 0x78, 0x17, 0x19, 0xdc, 0x98, 0xc8, 0xb5,
+		0x0a, 0xf3, 0x99, 0x33, 0x1a, 0xb9, 0x79, 0xdb, 0x67, 0xce, 0x49, 0x82,
+		0xa9, 0xe4, 0x14, 0xd5, 0x5d, 0x14, 0x81, 0xc3, 0x60, 0xff, 0x79, 0x24,
+		0xbc, 0x70, 0xff, 0xe4, 0xc4, 0x18, 0x54, 0xe2, 0x2e, 0xfa, 0x9d, 0xc4,
+		0x7f, 0xbd, 0x8e, 0xff, 0x37, 0x89, 0x5f, 0xfd, 0x11, 0x7f, 0xda, 0x3d,
+		0x1f, 0xed, 0x23, 0x50, 0xf8, 0xb9, 0x60, 0x0a, 0x29, 0x90, 0xc2, 0xc8,
+		0xa5, 0x4c, 0x0a, 0x1d, 0x26, 0x3f, 0xa6, 0xec, 0xb1, 0xcf, 0x0e, 0x45,
+		0x0c, 0x1e, 0x5b, 0xf2, 0x8e, 0x6c, 0x5c, 0xf9, 0x50, 0x3b, 0x32, 0xb2,
+		0x99, 0x3b, 0x1d, 0x67, 0x47, 0x46, 0x36, 0xb1, 0x7f, 0xeb, 0xb6, 0xe6,
+		0x1d, 0xd9, 0xb0, 0x22, 0x03, 0x51, 0x64, 0x0b, 0x54, 0x20, 0x97, 0x60,
+		0x88, 0x5e, 0x69, 0xc8, 0x95, 0x4c, 0x50, 0x6b, 0xa4, 0x90, 0xa3, 0x02,
+		0x8d, 0x89, 0x14, 0x74, 0x04, 0x52, 0xb9, 0xd7, 0x8c, 0x89, 0xc2, 0xa0,
+		0x7d, 0x4b, 0x65, 0xa1, 0x80, 0x68, 0x60, 0x02, 0xae, 0x27, 0xe3, 0xac,
+		0x4e, 0x64, 0x12, 0xbf, 0xfa, 0xb4, 0xbb, 0x1e, 0xed, 0xbf, 0x1e, 0xff,
+		0xae, 0xb3, 0xf4, 0xd3, 0x37, 0xdf, 0x1d, 0x90, 0x54, 0x26, 0xe4, 0xb3,
+		0x2f, 0x3a, 0xfc, 0x74, 0x9c, 0x0f, 0x60, 0xbc, 0x28, 0x94, 0x36, 0xc7,
+		0x90, 0x3f, 0x10, 0x83, 0xbe, 0x66, 0x28, 0x66, 0x0b, 0x75, 0xee, 0xb5,
+		0x1c, 0x68, 0xfb, 0x1e, 0x87, 0xf7, 0x06, 0xea, 0xeb, 0x8a, 0xf5, 0xaf,
+		0xce, 0x36, 0xc2, 0xb9, 0x5c, 0x5b, 0xbf, 0x30, 0x20, 0x45, 0x82, 0x40,
+		0x96, 0x06, 0x15, 0x98, 0x14, 0xc1, 0x79, 0x20, 0xa4, 0x44, 0xc3, 0x02,
+		0x51, 0x00, 0xa3, 0x1c, 0xbb, 0x88, 0x46, 0x35, 0x7e, 0x4f, 0x90, 0x1c,
+		0x46, 0x29, 0x91, 0x22, 0x29, 0x94, 0x42, 0xd1, 0x42, 0x75, 0x5f, 0xd5,
+		0xc0, 0x5a, 0xaa, 0x15, 0x2a, 0x7d, 0x89, 0x67, 0xd6, 0x74, 0x2b, 0xff,
+		0xac, 0x97, 0xf5, 0x7b, 0x69, 0xd6, 0xe1, 0x27, 0xaa, 0x10, 0x82, 0x89,
+		0x87, 0xd2, 0xf0, 0x4e, 0x5a, 0x7d, 0x6e, 0x37, 0x40, 0x6f, 0x18, 0x2a,
+		0xa3, 0x18, 0xea, 0x63, 0x4a, 0xf6, 0x83, 0x81, 0x8c, 0x6c, 0x7c, 0xed,
+		0x50, 0x42, 0x76, 0x16, 0x73, 0x8b, 0xc9, 0x6b, 0x3a, 0x42, 0xb6, 0x2c,
+		0xb6, 0x98, 0x42, 0x59, 0x37, 0xa1, 0xe6, 0x78, 0x43, 0x40, 0x9c, 0xb6,
+		0x89, 0x65, 0x28, 0x0b, 0xd3, 0x69, 0x55, 0xa8, 0xbb, 0xc8, 0xa6, 0x52,
+		0xef, 0x60, 0x51, 0x55, 0x72, 0xc2, 0x9e, 0x43, 0x9b, 0xbf, 0x67, 0x4d,
+		0xca, 0xb4, 0x91, 0x6a, 0x7b, 0x6c, 0xcd, 0x5b, 0x5f, 0x0c, 0x0a, 0xed,
+		0x16, 0xc8, 0xa4, 0x18, 0x6a, 0x52, 0xd0, 0x9b, 0x57, 0x1d, 0xbd, 0x5d,
+		0xa1, 0x38, 0xae, 0x15, 0x37, 0x8c, 0x9b, 0x54, 0xd6, 0x7d, 0x74, 0x11,
+		0x4d, 0x83, 0x91, 0xb0, 0x42, 0xcc, 0x81, 0x22, 0x67, 0x8f, 0xa8, 0xb6,
+		0x60, 0xed, 0xcc, 0x72, 0xa3, 0x47, 0x30, 0xa9, 0x2a, 0x97, 0x52, 0xe1,
+		0x23, 0xaa, 0x73, 0xa1, 0xe0, 0x29, 0x9a, 0xc3, 0x1c, 0x9c, 0x51, 0xcc,
+		0x72, 0x69, 0x50, 0x24, 0x2d, 0x84, 0x3f, 0x1d, 0xaa, 0x60, 0xcd, 0x04,
+		0x95, 0xeb, 0xa1, 0x0c, 0x6b, 0xa2, 0x73, 0xdf, 0xb3, 0xdc, 0x78, 0xab,
+		0xf2, 0xb8, 0x2c, 0x6f, 0x50, 0xfc, 0xe7, 0xcb, 0xdb, 0x49, 0x9b, 0x24,
+		0x71, 0x2b, 0x18, 0x18, 0x05, 0xa9, 0xea, 0x13, 0x86, 0x15, 0x6e, 0x81,
+		0x69, 0x50, 0x98, 0xa1, 0x5d, 0xeb, 0x48, 0x87, 0x72, 0x1c, 0xae, 0x38,
+		0x70, 0xdf, 0x21, 0xc9, 0x4a, 0x2e, 0x97, 0xad, 0x9d, 0x07, 0x8d, 0xda,
+		0x96, 0x95, 0xc7, 0xf0, 0x34, 0x72, 0x4c, 0x4c, 0xb9, 0xe3, 0xd8, 0x96,
+		0xf3, 0x52, 0xc6, 0x6f, 0x3a, 0xb6, 0x28, 0x3e, 0x56, 0x06, 0x98, 0xca,
+		0xdc, 0xfa, 0x5f, 0x99, 0xa9, 0xe0, 0x26, 0x97, 0xc2, 0xba, 0x24, 0xe1,
+		0xd1, 0xec, 0x87, 0xc3, 0xcb, 0x74, 0xec, 0xdb, 0xf5, 0x76, 0xe4, 0x4c,
+		0x20, 0x51, 0xd1, 0xec, 0x67, 0xf7, 0x3c, 0xdb, 0x7c, 0xc9, 0x36, 0x48,
+		0xa3, 0xd9, 0x8f, 0xf6, 0x71, 0xdc, 0x78, 0x3a, 0xf6, 0xc6, 0x0c, 0xf1,
+		0x37, 0xc1, 0xec, 0xec, 0x62, 0x8a, 0x9c, 0x6c, 0xbb, 0x79, 0x85, 0x26,
+		0xe0, 0x9a, 0x0c, 0xde, 0xb2, 0x1d, 0xc0, 0xd0, 0x75, 0xee, 0xd5, 0x6b,
+		0x18, 0x9b, 0xc3, 0x36, 0x9d, 0xee, 0xa6, 0xe5, 0x70, 0x0b, 0xb4, 0x6b,
+		0xc8, 0x6d, 0xb2, 0x4b, 0xa6, 0xb4, 0x01, 0x27, 0x32, 0xd4, 0xb9, 0xba,
+		0x7b, 0x0f, 0x73, 0x24, 0xbb, 0x17, 0x9c, 0x40, 0x63, 0x37, 0x81, 0x27,
+		0x60, 0xb1, 0xbb, 0x4e, 0x0b, 0xc9, 0x61, 0xa8, 0xbe, 0x48, 0xf6, 0xdf,
+		0x3c, 0x47, 0x05, 0x0b, 0x59, 0x08, 0x0a, 0x52, 0x78, 0x33, 0xfc, 0xf0,
+		0xc0, 0x44, 0xc8, 0xdc, 0x5c, 0xe4, 0x59, 0x4a, 0x05, 0x42, 0x02, 0x67,
+		0x19, 0x33, 0xe7, 0x28, 0x3d, 0x49, 0x74, 0x20, 0xbc, 0x82, 0x1b, 0x96,
+		0x73, 0x86, 0xaa, 0x87, 0x5e, 0x55, 0x7f, 0x21, 0xbe, 0x83, 0x6e, 0x9d,
+		0x5f, 0xad, 0xb4, 0xc7, 0x9f, 0xfe, 0xa5, 0xe4, 0xda, 0xa4, 0xb0, 0x24,
+		0x89, 0x91, 0xca, 0x19, 0x54, 0x5b, 0xad, 0x55, 0xd0, 0x38, 0x02, 0xf6,
+		0xed, 0xd7, 0x7f, 0x5c, 0xf9, 0x7f, 0xbe, 0xf9, 0xae, 0x0e, 0x6e, 0x90,
+		0xd8, 0x30, 0x50, 0x7f, 0x32, 0x3b, 0x60, 0x37, 0x24, 0x5f, 0x37, 0x20,
+		0x58, 0x05, 0x91, 0x1a, 0x91, 0x23, 0xd9, 0x56, 0x08, 0x89, 0x66, 0xff,
+		0x96, 0x02, 0xcf, 0x47, 0x9a, 0x82, 0xf3, 0x68, 0xf6, 0x63, 0xc1, 0xcf,
+		0xc7, 0x30, 0xfc, 0x5c, 0xb8, 0xb0, 0x67, 0x1f, 0xa7, 0x83, 0x92, 0x5f,
+		0xa1, 0xb9, 0x42, 0x42, 0x9d, 0xcf, 0x31, 0xd4, 0x20, 0x0b, 0x03, 0x5a,
+		0xda, 0x85, 0xba, 0x05, 0x2a, 0x41, 0x48, 0x03, 0x29, 0x33, 0xf6, 0x1d,
+		0x0c, 0x51, 0x0f, 0x68, 0x80, 0xf8, 0x37, 0x4d, 0x32, 0x74, 0x09, 0xcb,
+		0x40, 0x3f, 0x94, 0xb4, 0x7d, 0xd2, 0x93, 0x14, 0x4f, 0x42, 0x75, 0x9d,
+		0x7c, 0xda, 0x5c, 0xef, 0xde, 0x32, 0x39, 0x2f, 0x74, 0x1a, 0xcd, 0xde,
+		0x17, 0x3a, 0x3d, 0x4b, 0x27, 0x77, 0x20, 0xdf, 0x17, 0x7c, 0x08, 0x1b,
+		0xab, 0x08, 0x1a, 0x5d, 0x96, 0xe1, 0xf2, 0x65, 0xe3, 0xc8, 0x30, 0x15,
+		0x58, 0x8c, 0xc0, 0xca, 0x01, 0x47, 0xa3, 0xed, 0x01, 0x42, 0x17, 0x19,
+		0x2a, 0x0d, 0x1c, 0x89, 0x76, 0xa1, 0x6e, 0xe0, 0x01, 0x4d, 0xb3, 0x07,
+		0x9b, 0x84, 0xc7, 0x1a, 0x13, 0x85, 0xad, 0x14, 0xf4, 0xa3, 0xaf, 0x05,
+		0x5f, 0x7b, 0x6a, 0x91, 0xe6, 0x44, 0xeb, 0xb5, 0x54, 0xb4, 0x5c, 0xa8,
+		0x41, 0x77, 0x1e, 0x74, 0x1d, 0xc7, 0xa3, 0xb1, 0x7a, 0x92, 0x51, 0x3b,
+		0xa6, 0xcb, 0x37, 0x51, 0x1b, 0x6f, 0xb4, 0x33, 0x57, 0xc3, 0x9a, 0x99,
+		0x14, 0xde, 0xbe, 0x7b, 0x7d, 0x1f, 0x7f, 0x7c, 0xfb, 0xfa, 0xe6, 0xc5,
+		0xcb, 0x46, 0xa8, 0x1f, 0xda, 0x69, 0x64, 0x09, 0x3d, 0x22, 0xd8, 0x24,
+		0x6d, 0x0b, 0xc6, 0x3b, 0x99, 0x9d, 0xd8, 0x30, 0x5c, 0xb8, 0xc1, 0xa4,
+		0x30, 0xb2, 0xb5, 0x54, 0x7f, 0x08, 0xe5, 0x27, 0x3d, 0xaa, 0xea, 0xec,
+		0x68, 0xb4, 0xa4, 0x3a, 0x96, 0xe7, 0xf7, 0xdb, 0xd2, 0xf1, 0x75, 0x92,
+		0x62, 0xd6, 0xb1, 0x54, 0x77, 0x3b, 0x45, 0xc4, 0x03, 0x42, 0x39, 0x01,
+		0xbd, 0xdf, 0x1f, 0xa9, 0xec, 0x76, 0x57, 0xfb, 0xbd, 0xbb, 0x3b, 0xb2,
+		0xd7, 0x46, 0xa1, 0xff, 0x6e, 0x87, 0x82, 0xee, 0xf7, 0xfd, 0xde, 0xf7,
+		0xc6, 0x27, 0xb4, 0xc1, 0xf7, 0x46, 0x90, 0xb3, 0x64, 0x85, 0x14, 0x16,
+		0x5b, 0xbf, 0xfe, 0xdc, 0x6c, 0xec, 0x51, 0x0e, 0x49, 0x92, 0x96, 0x73,
+		0x2c, 0x04, 0x47, 0xad, 0x41, 0xa3, 0xb9, 0x0c, 0x66, 0xec, 0x27, 0xa5,
+		0xfb, 0xa0, 0x42, 0xa8, 0x3f, 0x86, 0x6b, 0x37, 0x06, 0xa2, 0x90, 0x1c,
+		0xe1, 0x9d, 0xfb, 0xe6, 0x4d, 0xca, 0xd5, 0x18, 0x4d, 0xaf, 0x5b, 0xe1,
+		0xf6, 0xce, 0x71, 0xaa, 0xdc, 0xef, 0x17, 0xdf, 0xce, 0xda, 0x66, 0x2d,
+		0x3d, 0x7c, 0x4a, 0xd3, 0x71, 0x39, 0x5e, 0x0d, 0xd3, 0x2f, 0x02, 0xa1,
+		0xd2, 0x70, 0x17, 0x26, 0x36, 0x8b, 0x1b, 0x01, 0xd1, 0x8d, 0xde, 0xe0,
+		0x6f, 0x05, 0xf5, 0x05, 0x0b, 0x13, 0x37, 0xcc, 0xc4, 0x42, 0xc6, 0x3e,
+		0xd3, 0x69, 0x91, 0x61, 0x06, 0x84, 0x2c, 0x13, 0x99, 0x61, 0x7b, 0xa7,
+		0x55, 0x9c, 0x0b, 0x39, 0xf7, 0x8a, 0x81, 0x4e, 0x7d, 0x90, 0x26, 0x9a,
+		0x97, 0xb7, 0x23, 0x78, 0xf9, 0xa2, 0xe2, 0xe2, 0x86, 0x4c, 0x24, 0x45,
+		0x87, 0x26, 0x91, 0x59, 0x46, 0x5c, 0x68, 0x4a, 0x89, 0x01, 0xa2, 0xd0,
+		0xad, 0x23, 0x1f, 0xc7, 0x69, 0x7d, 0x61, 0x5e, 0xd0, 0x6d, 0xe4, 0xab,
+		0x41, 0x63, 0x4e, 0x14, 0x31, 0x43, 0x53, 0x7d, 0xef, 0x7c, 0xb1, 0x77,
+		0xc9, 0xf6, 0x19, 0xba, 0xb6, 0x7a, 0x2e, 0xb8, 0x19, 0xb0, 0xbd, 0xe6,
+		0x41, 0x32, 0x1c, 0xa3, 0xeb, 0xa3, 0x34, 0x49, 0xa5, 0xc6, 0xe4, 0x23,
+		0xb0, 0x7f, 0xf5, 0x21, 0x55, 0xfb, 0xf0, 0x73, 0x39, 0x6a, 0x15, 0x8b,
+		0x32, 0xb2, 0x85, 0x42, 0x63, 0x23, 0xf9, 0xea, 0x6f, 0xf6, 0xf7, 0x70,
+		0xb8, 0x5b, 0xaa, 0x1e, 0x1a, 0xae, 0xee, 0x42, 0x16, 0x5e, 0xaf, 0x8e,
+		0x22, 0x94, 0x34, 0x48, 0x90, 0x9c, 0x5d, 0xe1, 0x86, 0x64, 0x39, 0xc7,
+		0xab, 0x44, 0x66, 0x23, 0xf8, 0x47, 0xf3, 0xf5, 0x66, 0xf2, 0xfc, 0x6a,
+		0x72, 0x75, 0x7d, 0xfd, 0xfc, 0x6a, 0x32, 0xbe, 0xb9, 0xad, 0x60, 0xbd,
+		0x95, 0x2e, 0x6a, 0x97, 0xf6, 0x17, 0xda, 0x40, 0x46, 0x4c, 0x92, 0xd6,
+		0x49, 0xb9, 0x36, 0x56, 0x90, 0x4a, 0x7b, 0xb5, 0xae, 0x47, 0x40, 0x28,
+		0x55, 0xa8, 0xb5, 0xf5, 0x2c, 0x05, 0xf7, 0x3f, 0xbd, 0xf9, 0x00, 0x2e,
+		0x14, 0x76, 0x09, 0x8d, 0x42, 0xbc, 0xb7, 0x49, 0x1a, 0x11, 0xdb, 0x8b,
+		0x48, 0x52, 0x14, 0xdb, 0x1e, 0x90, 0xb6, 0xea, 0x42, 0x8e, 0x4e, 0xad,
+		0x8e, 0xd1, 0x17, 0x34, 0x28, 0xe6, 0x8a, 0x3d, 0xba, 0x5b, 0xe4, 0x7e,
+		0x3c, 0x42, 0x06, 0xcb, 0xbe, 0x04, 0xa2, 0x4a, 0x6c, 0x04, 0x61, 0x68,
+		0x07, 0x8a, 0x09, 0x9b, 0x04, 0x13, 0x7e, 0x50, 0x19, 0x7e, 0x64, 0xca,
+		0xc9, 0x96, 0x4b, 0x42, 0xbb, 0xae, 0xd7, 0x43, 0xd5, 0x25, 0xf7, 0x98,
+		0xa5, 0x5a, 0x75, 0x89, 0x59, 0x15, 0xf4, 0x1c, 0x94, 0xca, 0x2b, 0xc7,
+		0xd0, 0x0e, 0x34, 0xfb, 0x0b, 0x81, 0x09, 0x58, 0x6c, 0x4d, 0xfb, 0xbe,
+		0xee, 0xdb, 0xa8, 0xe3, 0xb2, 0xb2, 0xb3, 0xe7, 0xd3, 0x4e, 0x41, 0x0b,
+		0x85, 0x64, 0x85, 0x2a, 0x36, 0xa9, 0x42, 0x6d, 0xe7, 0x7a, 0x4c, 0xe5,
+		0x7b, 0xdf, 0x00, 0xaa, 0x06, 0x43, 0xd9, 0x04, 0xe5, 0xf9, 0x41, 0xd9,
+		0x11, 0x6a, 0x0f, 0xd8, 0xc7, 0xe9, 0x5e, 0x0a, 0x6d, 0x77, 0x28, 0xf6,
+		0x88, 0xb0, 0x24, 0x8c, 0x17, 0xca, 0xc7, 0x69, 0x52, 0x6e, 0xe9, 0xa9,
+		0xd4, 0xc6, 0x07, 0x6b, 0x6d, 0x64, 0x0e, 0x94, 0xe9, 0xdc, 0x3a, 0x0a,
+		0x18, 0x09, 0xcc, 0xd4, 0x70, 0x84, 0x21, 0xcf, 0x9d, 0x35, 0xbf, 0xf4,
+		0x78, 0x97, 0x7d, 0x00, 0x89, 0x94, 0x9c, 0xca, 0xb5, 0xe8, 0xe3, 0x5f,
+		0xd6, 0x5f, 0x8a, 0xbf, 0xd2, 0x6d, 0xd0, 0x3f, 0x94, 0x36, 0xe0, 0x3f,
+		0x9f, 0xf4, 0xdd, 0x6e, 0x10, 0xc8, 0x95, 0x5c, 0x20, 0x30, 0x0d, 0x1a,
+		0x85, 0xb1, 0x36, 0x13, 0x47, 0xc9, 0x66, 0xe0, 0x16, 0xcc, 0x85, 0xf7,
+		0x1d, 0x67, 0xf5, 0x2e, 0x58, 0xce, 0x28, 0x28, 0x13, 0x0f, 0x9d, 0xcb,
+		0xd9, 0x57, 0x5d, 0xb4, 0x9c, 0x83, 0xda, 0x61, 0x39, 0x97, 0x05, 0x3d,
+		0x6e, 0xfa, 0x1b, 0x61, 0xc6, 0x4e, 0x9a, 0x08, 0xea, 0x76, 0x4b, 0x5a,
+		0x70, 0xa4, 0xe5, 0xa9, 0xe8, 0xf0, 0xc5, 0x8b, 0xe4, 0x54, 0x9f, 0x63,
+		0x74, 0x81, 0xd4, 0x13, 0xef, 0x3c, 0x0e, 0xf6, 0xc4, 0x3e, 0xdc, 0xf4,
+		0x43, 0xf3, 0x51, 0xe5, 0x09, 0xe8, 0xe6, 0x5e, 0xf9, 0x18, 0x60, 0x18,
+		0xb0, 0x0f, 0xe3, 0xfb, 0x10, 0xd3, 0x5c, 0xab, 0x32, 0xc1, 0x5d, 0xf7,
+		0x03, 0x39, 0x19, 0x28, 0x2f, 0x15, 0x7b, 0x1a, 0x4d, 0xf9, 0x88, 0x6a,
+		0xd9, 0x91, 0xc9, 0xfc, 0x96, 0xa2, 0x80, 0xa5, 0x3b, 0x48, 0x9f, 0x38,
+		0x70, 0x55, 0xbd, 0x1d, 0xa8, 0x96, 0x56, 0xeb, 0xc0, 0xa5, 0xf0, 0x4f,
+		0x4c, 0x4c, 0x34, 0xfb, 0xe0, 0x9e, 0x20, 0x70, 0xed, 0xe7, 0x7e, 0xf6,
+		0x60, 0x4f, 0x95, 0xcc, 0x63, 0xcb, 0x5b, 0x9b, 0x68, 0xf6, 0x46, 0xc9,
+		0x1c, 0xfc, 0x4b, 0x77, 0xf7, 0x8e, 0xe3, 0x56, 0x18, 0x91, 0x08, 0xbd,
+		0x46, 0xa5, 0x0f, 0x23, 0xfb, 0xf3, 0xeb, 0xed, 0xcd, 0xab, 0x11, 0xd0,
+		0x9a, 0x6c, 0x46, 0x56, 0xa8, 0x41, 0x49, 0x99, 0x39, 0xa6, 0xc3, 0x4f,
+		0x15, 0xfe, 0x5e, 0xc8, 0x7d, 0x65, 0x19, 0x77, 0x6d, 0x45, 0xee, 0xca,
+		0x29, 0x7e, 0x6d, 0xeb, 0xe1, 0xdc, 0x4e, 0x94, 0xa4, 0x98, 0xac, 0x16,
+		0x72, 0xd3, 0xbc, 0x9b, 0x73, 0xda, 0xf3, 0xc3, 0x66, 0xd4, 0x1a, 0xb1,
+		0x64, 0x66, 0x54, 0x81, 0xcd, 0xec, 0x85, 0x53, 0xef, 0x44, 0xa9, 0xe4,
+		0xe5, 0x42, 0x5c, 0xdb, 0xcf, 0xb9, 0xda, 0x1d, 0x4a, 0x3c, 0xb7, 0x37,
+		0xaf, 0x40, 0x2a, 0x78, 0x31, 0x79, 0xee, 0xf9, 0xd4, 0x66, 0x7d, 0x0e,
+		0xc3, 0xa2, 0x30, 0xa6, 0x71, 0xd4, 0xf4, 0x05, 0xc1, 0x24, 0x5d, 0x2c,
+		0xdc, 0x8d, 0x69, 0xd9, 0xd8, 0x88, 0x68, 0x76, 0xef, 0x7e, 0x34, 0x31,
+		0x1d, 0xfb, 0x86, 0xdd, 0xfd, 0x12, 0x22, 0x12, 0xe4, 0xf5, 0x7e, 0x50,
+		0x16, 0x49, 0x91, 0x70, 0x96, 0xac, 0xee, 0x22, 0xff, 0xfd, 0xca, 0x15,
+		0x97, 0x09, 0x71, 0x3f, 0xbc, 0xf8, 0x6a, 0xfc, 0x55, 0x34, 0xbb, 0x77,
+		0xad, 0x9a, 0xe2, 0xd5, 0xcc, 0xa7, 0x63, 0xfb, 0x4b, 0x8d, 0xd9, 0xb3,
+		0x50, 0x50, 0x9e, 0xd9, 0xff, 0x3f, 0x00, 0xd8, 0x8f, 0x36, 0xad, 0x64,
+		0x22, 0x00, 0x00,
 	},
 		"template/queue_create.html",
 	)
 }
 
+func template_queue_edit_html() ([]byte, error) {
+	return bindata_read([]byte{
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x9a,
+		0x6d, 0x8f, 0xdb, 0x36, 0x12, 0xc7, 0xdf, 0xe7, 0x53, 0x0c, 0x84, 0x00,
+		0x6d, 0xaf, 0x7e, 0xd8, 0x4d, 0xd2, 0xe0, 0x72, 0xb0, 0x5d, 0x34, 0xbb,
+		0xbd, 0x4b, 0x8a, 0x6e, 0x13, 0x6c, 0x72, 0xc8, 0x8b, 0x5e, 0x61, 0xd0,
+		0xe2, 0x38, 0x62, 0x2d, 0x91, 0x0a, 0x49, 0xad, 0xed, 0x33, 0xf4, 0xdd,
+		0x0b, 0x3e, 0xc9, 0x92, 0x2d, 0xd9, 0xf2, 0x36, 0x6f, 0x92, 0x95, 0x38,
+		0xfc, 0x0f, 0xf9, 0x1b, 0x72, 0xf8, 0x20, 0xef, 0x76, 0x14, 0x97, 0x8c,
+		0x23, 0x44, 0x19, 0x61, 0x3c, 0x2a, 0xcb, 0x27, 0x13, 0xca, 0x1e, 0x20,
+		0x4e, 0x89, 0x52, 0xd3, 0x28, 0x16, 0x5c, 0x23, 0xd7, 0x10, 0x4b, 0x24,
+		0x1a, 0x87, 0x5f, 0x0a, 0x2c, 0x10, 0x90, 0x32, 0xed, 0xfe, 0x8c, 0x66,
+		0x4f, 0x00, 0x26, 0xc9, 0x75, 0x30, 0xd7, 0x4c, 0xa7, 0x18, 0xcd, 0x26,
+		0x04, 0x12, 0x89, 0xcb, 0x69, 0x34, 0xb6, 0x56, 0xe3, 0xdd, 0x6e, 0x74,
+		0x8f, 0xaa, 0x48, 0xf5, 0xe8, 0xed, 0x6d, 0x59, 0x8e, 0x4d, 0xfd, 0x68,
+		0xb6, 0xdb, 0x8d, 0x3e, 0x1a, 0xf3, 0xb2, 0x9c, 0x8c, 0xc9, 0x6c, 0x32,
+		0x4e, 0xae, 0xad, 0xd8, 0x52, 0xc8, 0x0c, 0x18, 0x9d, 0x46, 0xb6, 0xea,
+		0xd0, 0xfa, 0x32, 0xef, 0xa2, 0xe0, 0xc3, 0xbd, 0xf7, 0x0d, 0x72, 0x25,
+		0x24, 0xd6, 0x4c, 0xf0, 0x93, 0xee, 0x20, 0x43, 0x9d, 0x08, 0x3a, 0x8d,
+		0x72, 0xa1, 0xb4, 0x6d, 0x36, 0x40, 0xbd, 0xa3, 0x8c, 0xe7, 0x85, 0x06,
+		0x49, 0x34, 0xfa, 0x42, 0x80, 0x49, 0x4a, 0x16, 0x98, 0xce, 0xee, 0xc8,
+		0xc6, 0xbe, 0x9f, 0x8c, 0xdd, 0x73, 0x28, 0x75, 0x35, 0xf4, 0x36, 0xc7,
+		0x69, 0xa4, 0x71, 0xa3, 0x23, 0xe0, 0x24, 0xc3, 0x69, 0x94, 0x91, 0xcd,
+		0xdc, 0xea, 0xc0, 0x03, 0x49, 0x0b, 0x9c, 0x46, 0xbb, 0xdd, 0x3d, 0xd1,
+		0x08, 0xa1, 0x4d, 0x37, 0x82, 0x2f, 0xd9, 0xe7, 0xd1, 0x1d, 0xd9, 0xb4,
+		0xbd, 0x36, 0xef, 0xde, 0xa3, 0x64, 0x82, 0x96, 0x65, 0x64, 0x39, 0x64,
+		0x64, 0x33, 0x74, 0x7a, 0x79, 0x4a, 0x62, 0x4c, 0x44, 0x4a, 0x51, 0x4e,
+		0xa3, 0x08, 0x2c, 0xec, 0x69, 0x74, 0x47, 0x36, 0xac, 0xc8, 0x80, 0x17,
+		0xd9, 0x02, 0x25, 0x88, 0x25, 0x68, 0xa2, 0x56, 0x0a, 0x72, 0x29, 0x62,
+		0x54, 0x0a, 0x29, 0xe4, 0x28, 0x41, 0x61, 0x2c, 0x38, 0x1d, 0x80, 0x90,
+		0xf6, 0x31, 0x63, 0xbc, 0xd0, 0x68, 0x9e, 0x12, 0x51, 0x48, 0x20, 0x0a,
+		0x18, 0x87, 0xeb, 0xab, 0x71, 0x16, 0x41, 0x4e, 0xb4, 0x46, 0xc9, 0xa7,
+		0xd1, 0xef, 0x57, 0xc3, 0x57, 0x7f, 0xec, 0xae, 0x07, 0xe5, 0xb7, 0xe3,
+		0xdf, 0x55, 0x96, 0xfc, 0xf1, 0xdd, 0x8f, 0x11, 0x48, 0xfc, 0x52, 0x30,
+		0x89, 0xb4, 0x82, 0x90, 0xcf, 0xbe, 0xaa, 0xfb, 0xc9, 0x38, 0xf7, 0xa1,
+		0x19, 0x53, 0xf6, 0xd0, 0x15, 0xa5, 0x45, 0x21, 0x95, 0x3e, 0x0c, 0x93,
+		0x85, 0x69, 0x4b, 0xfa, 0x06, 0xca, 0x40, 0x9d, 0x3b, 0xad, 0x7d, 0xa8,
+		0x5a, 0xc2, 0xf1, 0xda, 0x98, 0x84, 0x68, 0x98, 0x4a, 0x43, 0x5f, 0xa9,
+		0x11, 0x8f, 0xeb, 0x2a, 0x20, 0x1f, 0x2d, 0x00, 0x92, 0xa6, 0x62, 0x8d,
+		0x14, 0x88, 0x06, 0xc1, 0x63, 0x04, 0xb2, 0xd4, 0x28, 0x41, 0x27, 0x08,
+		0x6e, 0x12, 0x25, 0x44, 0xc1, 0x02, 0x91, 0x03, 0xa3, 0x29, 0xb6, 0x61,
+		0x8f, 0x6a, 0x90, 0x1f, 0x21, 0xd9, 0x0f, 0x65, 0x2c, 0x78, 0x5c, 0x48,
+		0x89, 0xfc, 0x88, 0xe7, 0x4d, 0x55, 0x02, 0x6b, 0x21, 0x57, 0x28, 0xd5,
+		0x25, 0x13, 0xa0, 0xa6, 0xdb, 0xc9, 0xf6, 0x8e, 0x6c, 0xf6, 0x4e, 0xea,
+		0xa3, 0xbd, 0x5e, 0xb9, 0x7b, 0xcc, 0x67, 0x2d, 0xa3, 0x4e, 0x16, 0x9c,
+		0x33, 0xfe, 0x39, 0x10, 0x6a, 0xc5, 0xda, 0x35, 0x88, 0x7b, 0xe8, 0xf5,
+		0x63, 0xaa, 0x25, 0x43, 0x75, 0x88, 0xd3, 0x44, 0x10, 0x32, 0xb2, 0x71,
+		0xa5, 0x7d, 0x51, 0x9a, 0x56, 0xcc, 0x0d, 0x4f, 0xa7, 0xd9, 0x89, 0xd2,
+		0xa8, 0xdf, 0x91, 0xcd, 0x47, 0x63, 0x15, 0x48, 0x9a, 0xba, 0x43, 0x83,
+		0xd3, 0xd7, 0x6d, 0x27, 0xd9, 0x6c, 0x57, 0x1f, 0x60, 0xa7, 0xfb, 0xce,
+		0x32, 0x14, 0x85, 0x6e, 0xed, 0xbd, 0x2f, 0xbb, 0xa8, 0xef, 0x41, 0xef,
+		0x64, 0xcf, 0x3f, 0x3a, 0xa3, 0x46, 0xc7, 0xab, 0x8a, 0x27, 0xba, 0xbd,
+		0xb7, 0xf9, 0x7b, 0x9d, 0x4e, 0x98, 0xd2, 0x42, 0x6e, 0x0f, 0x3b, 0xfd,
+		0xc6, 0xbd, 0x06, 0x89, 0x66, 0x09, 0x65, 0x82, 0xf7, 0xed, 0xb9, 0xd7,
+		0x9b, 0x57, 0x15, 0xbb, 0xbb, 0xef, 0x7d, 0xdc, 0x07, 0xcb, 0xc0, 0xc0,
+		0x4b, 0x0c, 0x6b, 0x12, 0x0d, 0x10, 0x57, 0x15, 0x89, 0x0f, 0x36, 0x35,
+		0x2b, 0xd0, 0x02, 0x56, 0x88, 0x39, 0x50, 0x4c, 0xd9, 0x03, 0xca, 0x2d,
+		0x18, 0x26, 0x59, 0xae, 0xd5, 0x00, 0xae, 0xaa, 0xc2, 0xa5, 0x90, 0xf8,
+		0x80, 0xf2, 0x5c, 0xba, 0x7a, 0x8c, 0x66, 0xbf, 0xb9, 0xc5, 0x28, 0x66,
+		0xb9, 0xd0, 0xc8, 0xe3, 0x23, 0xdc, 0x6f, 0xf7, 0x45, 0xb0, 0x66, 0x9c,
+		0x8a, 0x75, 0x5f, 0xde, 0x35, 0xd1, 0xb9, 0xab, 0xd9, 0x0d, 0xbc, 0xe6,
+		0xe5, 0x93, 0x35, 0x0d, 0xc4, 0x6b, 0x22, 0xc3, 0x20, 0xd2, 0x40, 0xfe,
+		0xcf, 0x97, 0x2f, 0xae, 0x8e, 0xb1, 0x13, 0x9b, 0x69, 0x80, 0x51, 0x10,
+		0xb2, 0xde, 0x3b, 0x58, 0xe1, 0x16, 0x98, 0x02, 0x89, 0x19, 0x9a, 0x9c,
+		0x84, 0xb4, 0x2f, 0xf4, 0xfe, 0x8a, 0x3d, 0x57, 0x5b, 0x12, 0xaf, 0xc4,
+		0x72, 0x79, 0xb4, 0xde, 0xa2, 0x96, 0xdb, 0x50, 0x78, 0x48, 0x5a, 0x61,
+		0x8a, 0xb1, 0x0e, 0xeb, 0xac, 0xb1, 0x9c, 0x07, 0x19, 0xb7, 0x8a, 0x9a,
+		0x57, 0xc3, 0x43, 0x65, 0x80, 0x89, 0xc8, 0xcd, 0x60, 0x0d, 0xf4, 0x71,
+		0x93, 0x0b, 0x6e, 0xc6, 0x2f, 0x49, 0xa3, 0xdd, 0x8e, 0x2d, 0x01, 0xbf,
+		0x1c, 0xed, 0x97, 0x8c, 0xd2, 0xe8, 0xb5, 0x53, 0x82, 0x46, 0x8d, 0xb2,
+		0x04, 0xd7, 0x0e, 0xa4, 0xbb, 0x1d, 0x72, 0x5a, 0x96, 0xb3, 0x9f, 0xf7,
+		0xc5, 0x93, 0xb1, 0xf3, 0xd5, 0xe9, 0x3c, 0x65, 0x1c, 0x89, 0xec, 0xe9,
+		0xd7, 0x1b, 0xb7, 0xb8, 0xfc, 0xd5, 0x96, 0x9c, 0xf5, 0xb6, 0x64, 0x1b,
+		0xa4, 0x3d, 0x9d, 0x39, 0xdb, 0x16, 0x5f, 0xff, 0x36, 0x05, 0x87, 0xae,
+		0x26, 0x63, 0x67, 0xd6, 0x67, 0x76, 0x71, 0x66, 0xd0, 0x0c, 0x29, 0xa6,
+		0x64, 0xdb, 0x1e, 0x70, 0x6f, 0x02, 0xd6, 0xa4, 0xf7, 0x4e, 0xcb, 0x8e,
+		0x00, 0x5f, 0x75, 0xee, 0xd4, 0xbb, 0xb7, 0x5c, 0xb6, 0xb3, 0x6f, 0x9d,
+		0xf1, 0xad, 0xb1, 0xad, 0xf6, 0x5e, 0x76, 0xd4, 0x34, 0x1b, 0xd9, 0x9c,
+		0x63, 0xcf, 0x8e, 0xe6, 0xd7, 0x02, 0x4d, 0x7e, 0xb1, 0x9b, 0xa4, 0x25,
+		0x93, 0x4a, 0x83, 0x15, 0xe9, 0x3b, 0x97, 0xda, 0x6b, 0xf7, 0x9b, 0x37,
+		0x66, 0xe9, 0x3d, 0x01, 0xd2, 0xac, 0xb9, 0x8f, 0x80, 0x68, 0x36, 0x03,
+		0xbd, 0x00, 0xde, 0x91, 0x4d, 0x0b, 0xbc, 0x7d, 0xa3, 0xba, 0xd6, 0x83,
+		0xff, 0xe6, 0x39, 0x4a, 0x58, 0x88, 0x82, 0x53, 0x10, 0xdc, 0x75, 0xd8,
+		0x35, 0x14, 0x18, 0xf7, 0x1b, 0x79, 0x9b, 0xbf, 0x97, 0x42, 0x02, 0x17,
+		0x90, 0xb2, 0x8c, 0xe9, 0x73, 0x3c, 0x1f, 0x25, 0xda, 0x13, 0x73, 0x91,
+		0x6a, 0x96, 0xa7, 0x0c, 0x65, 0x07, 0xe7, 0xaa, 0xfc, 0x42, 0xd0, 0x7b,
+		0xdd, 0x73, 0xa4, 0x2b, 0xcb, 0x03, 0xd6, 0x35, 0x85, 0x8e, 0x51, 0xfa,
+		0x1f, 0x29, 0xd6, 0x3a, 0x81, 0x25, 0x89, 0xb5, 0x90, 0xb6, 0xf3, 0xb5,
+		0x04, 0x56, 0x65, 0xde, 0x03, 0xb8, 0xdf, 0x7f, 0xfb, 0xbf, 0x91, 0xfb,
+		0xe3, 0xbb, 0x1f, 0xeb, 0x90, 0x7b, 0x89, 0xf5, 0x83, 0xfa, 0x27, 0x33,
+		0x0e, 0xdb, 0x81, 0xba, 0xb2, 0x1e, 0x19, 0xdf, 0x8b, 0xd4, 0x88, 0x1c,
+		0xc8, 0x1e, 0x25, 0xc1, 0x68, 0xf6, 0x9b, 0xe0, 0x78, 0x3e, 0x57, 0x16,
+		0xe9, 0x99, 0xf5, 0xe0, 0x17, 0xeb, 0x08, 0x9c, 0x65, 0x5b, 0xa2, 0x2c,
+		0xd2, 0xf3, 0x0b, 0x00, 0x7e, 0x29, 0x48, 0x4f, 0x3f, 0xce, 0xb4, 0x6d,
+		0xc1, 0x31, 0x05, 0xa7, 0x33, 0xb2, 0x4b, 0x38, 0xb9, 0x44, 0x42, 0xed,
+		0xc4, 0x60, 0xa8, 0x40, 0x14, 0x1a, 0x94, 0x30, 0x79, 0x67, 0x0b, 0x54,
+		0x00, 0x17, 0x66, 0x93, 0xa9, 0xcd, 0x33, 0x68, 0x22, 0x3f, 0xa3, 0x06,
+		0xe2, 0x9e, 0x14, 0xc9, 0xd0, 0xee, 0x63, 0x7b, 0x4e, 0x16, 0x41, 0x8f,
+		0xef, 0x37, 0x04, 0xc5, 0x93, 0xd1, 0xb4, 0x95, 0xdc, 0xe1, 0xac, 0x5e,
+		0xfd, 0x88, 0x57, 0x5e, 0xa8, 0x24, 0x9a, 0xbd, 0x2f, 0x54, 0x72, 0x16,
+		0x6d, 0x7e, 0x22, 0x82, 0xa6, 0x3d, 0xe0, 0x2c, 0x5a, 0x80, 0xbe, 0x2f,
+		0xd2, 0x3e, 0x3c, 0x4d, 0x2b, 0x40, 0xa1, 0xdd, 0x84, 0xda, 0x93, 0x9c,
+		0xb6, 0x34, 0x99, 0xf4, 0xfc, 0x06, 0x60, 0x1c, 0x40, 0x8a, 0x5a, 0x99,
+		0x33, 0xb0, 0x2a, 0x32, 0x94, 0x0a, 0x52, 0x24, 0xca, 0x66, 0xfb, 0x9e,
+		0x17, 0x11, 0xb8, 0xc1, 0xb8, 0xd0, 0xe2, 0x68, 0xa2, 0xfc, 0xec, 0xdf,
+		0x9f, 0xc4, 0x5a, 0x55, 0xb6, 0x68, 0x8f, 0xa4, 0x5a, 0x26, 0xc7, 0xeb,
+		0x6d, 0x88, 0xbe, 0x8a, 0x13, 0xcc, 0x5a, 0x26, 0xca, 0x6e, 0x27, 0x09,
+		0xff, 0x8c, 0x10, 0x1a, 0xa0, 0xca, 0xf2, 0x40, 0x65, 0xb7, 0x1b, 0x95,
+		0x65, 0x85, 0xfe, 0xe9, 0x01, 0xfb, 0x50, 0x0f, 0x46, 0x2d, 0xe8, 0x6d,
+		0xd5, 0xca, 0xa7, 0x7f, 0xdb, 0x1d, 0x82, 0x5b, 0xb7, 0xe9, 0xf7, 0x01,
+		0x18, 0x40, 0xce, 0xe2, 0x15, 0x52, 0x58, 0x6c, 0xdd, 0xc0, 0xb5, 0x3d,
+		0x00, 0xb1, 0x04, 0x24, 0x71, 0x12, 0xfa, 0x55, 0xf0, 0x14, 0x95, 0x02,
+		0x85, 0xfa, 0xb2, 0x00, 0x0c, 0x5d, 0xa3, 0x54, 0x57, 0x20, 0xc0, 0x97,
+		0x1f, 0x06, 0xc4, 0xa4, 0x7d, 0x22, 0x91, 0x1c, 0x84, 0x64, 0xee, 0xcc,
+		0x9b, 0x91, 0xa9, 0x7c, 0x34, 0x93, 0xf9, 0x0a, 0xb7, 0x53, 0xcb, 0xb6,
+		0x4a, 0xea, 0xef, 0x9c, 0x9d, 0xe9, 0x9b, 0xe9, 0xe9, 0x3e, 0xb2, 0x21,
+		0x3a, 0x07, 0xd0, 0x9d, 0xbd, 0xd9, 0x27, 0xaa, 0xb2, 0xb4, 0x90, 0x9f,
+		0x78, 0xb8, 0x93, 0x71, 0x68, 0x60, 0x8d, 0xeb, 0x3b, 0x8e, 0x50, 0x39,
+		0xb5, 0xd7, 0x69, 0x66, 0xf3, 0x39, 0x00, 0xa2, 0x1a, 0xee, 0xc0, 0x5d,
+		0xeb, 0xaa, 0x8b, 0x86, 0x33, 0xd3, 0x43, 0x2e, 0x86, 0x6e, 0x8b, 0x74,
+		0x84, 0x92, 0x69, 0xe0, 0x22, 0xec, 0x80, 0xfa, 0x2d, 0xa5, 0x46, 0x71,
+		0xce, 0xc5, 0xdc, 0x29, 0xee, 0xc7, 0xa0, 0xe3, 0xf0, 0x94, 0x0d, 0xe0,
+		0x69, 0x0c, 0xff, 0x9a, 0xc2, 0xd1, 0x30, 0x64, 0xfa, 0x37, 0x61, 0x53,
+		0xac, 0x21, 0xc2, 0x96, 0xf0, 0x94, 0x95, 0xe5, 0x00, 0x3c, 0x96, 0xdd,
+		0xee, 0x69, 0x5c, 0x96, 0xfe, 0x21, 0x04, 0xa9, 0xde, 0xf4, 0x66, 0x84,
+		0x5e, 0xbe, 0x18, 0xc0, 0xcb, 0x1f, 0xaa, 0xf0, 0xd8, 0x8e, 0xc4, 0x82,
+		0xa2, 0x8d, 0x50, 0x2c, 0xb2, 0x8c, 0xd8, 0x34, 0x91, 0x10, 0x0d, 0x44,
+		0xa2, 0x4d, 0xb6, 0x2e, 0x0f, 0xd3, 0xfa, 0xea, 0x7a, 0x41, 0xb5, 0x81,
+		0x2b, 0x06, 0x85, 0x39, 0x91, 0x44, 0xf7, 0x3d, 0x68, 0xb9, 0x39, 0x30,
+		0x74, 0x33, 0xe3, 0xf8, 0x06, 0xa5, 0x36, 0xf1, 0x2f, 0xb8, 0x3f, 0x32,
+		0xb5, 0xe6, 0x5e, 0x72, 0xcf, 0xff, 0x17, 0xc1, 0x38, 0x1c, 0xdd, 0xa4,
+		0x18, 0xdb, 0xd1, 0x07, 0xe7, 0x01, 0xa2, 0x01, 0x44, 0xfb, 0x1b, 0x95,
+		0x7a, 0xcb, 0x9a, 0x74, 0x13, 0xad, 0xf3, 0x01, 0x98, 0x7f, 0xd5, 0x7e,
+		0x0f, 0x79, 0xff, 0x6b, 0x68, 0xa9, 0xef, 0x95, 0x82, 0x8c, 0x6c, 0xa1,
+		0x50, 0xd8, 0xd8, 0x15, 0x76, 0x9b, 0xfd, 0x3d, 0x84, 0xf6, 0xa2, 0xb4,
+		0x83, 0xa0, 0x2d, 0xbb, 0x90, 0x9f, 0xd3, 0xeb, 0x85, 0xef, 0x27, 0x63,
+		0xda, 0x06, 0xcf, 0x6b, 0x34, 0xd8, 0x91, 0x9c, 0x8d, 0x70, 0x43, 0xb2,
+		0x3c, 0xc5, 0x51, 0x2c, 0xb2, 0x01, 0xfc, 0xa3, 0xf9, 0xf8, 0xec, 0xea,
+		0xf9, 0xe8, 0x6a, 0x74, 0x7d, 0xfd, 0x7c, 0x74, 0x35, 0x7e, 0xf6, 0xa2,
+		0xc2, 0xfb, 0x46, 0x28, 0x5d, 0x23, 0x56, 0x28, 0x0d, 0x19, 0xd1, 0x71,
+		0x52, 0x67, 0x6b, 0x6d, 0x8c, 0x20, 0x15, 0xe6, 0xbb, 0x8e, 0x1a, 0x00,
+		0xa1, 0x54, 0xa2, 0x52, 0x66, 0xfc, 0x4a, 0xb8, 0x79, 0x7b, 0x7b, 0x0f,
+		0x76, 0x16, 0xb6, 0x09, 0x0d, 0xc0, 0xdc, 0xd2, 0x6c, 0xed, 0x1e, 0x92,
+		0xf0, 0xed, 0x45, 0xec, 0x29, 0xf2, 0x6d, 0x07, 0x7a, 0x53, 0x74, 0x21,
+		0x79, 0xab, 0xd6, 0x0b, 0xfc, 0x2d, 0xf2, 0x6d, 0x1b, 0x77, 0xa7, 0xd0,
+		0xc0, 0x9e, 0x4b, 0xf6, 0x60, 0x3f, 0xa8, 0x74, 0xf3, 0xe4, 0xc2, 0xa3,
+		0xf8, 0x1a, 0x4c, 0x2b, 0xb1, 0x01, 0x78, 0xd7, 0x96, 0x2c, 0xe3, 0x66,
+		0x53, 0x4f, 0xd2, 0xbd, 0x4a, 0xff, 0x83, 0x65, 0x4e, 0xb6, 0xa9, 0x20,
+		0xb4, 0xed, 0x5b, 0x95, 0x2f, 0xba, 0xe4, 0xb6, 0x3e, 0xa8, 0x9d, 0xb8,
+		0x65, 0xb5, 0x8c, 0xef, 0xc8, 0xe6, 0xbd, 0x33, 0xad, 0x5f, 0xd7, 0x57,
+		0xb5, 0x3b, 0x4e, 0x94, 0xe1, 0x72, 0xdd, 0xdb, 0x81, 0x62, 0xff, 0x47,
+		0x60, 0x1c, 0x16, 0x5b, 0x7d, 0x7c, 0xe3, 0xfc, 0x7d, 0xd4, 0x72, 0x2d,
+		0xdf, 0x5a, 0xf3, 0x71, 0xc7, 0xc5, 0x85, 0x44, 0xb2, 0x42, 0x39, 0xd4,
+		0x89, 0x44, 0x65, 0xda, 0x7a, 0x88, 0xf0, 0xb5, 0x33, 0x80, 0xca, 0xa0,
+		0x2f, 0x48, 0xaf, 0x3c, 0xdf, 0x2b, 0x77, 0xe2, 0xf4, 0x3e, 0x3e, 0x06,
+		0xcb, 0x40, 0xf3, 0xb8, 0x71, 0x5d, 0x4c, 0x6f, 0x04, 0x57, 0x66, 0x9d,
+		0x67, 0x0f, 0x08, 0x4b, 0xc2, 0xd2, 0x42, 0xba, 0x75, 0x89, 0x84, 0x9d,
+		0x54, 0x22, 0x94, 0x76, 0x8b, 0x93, 0xd2, 0x22, 0x07, 0xca, 0x54, 0x6e,
+		0x46, 0x20, 0x68, 0x01, 0x4c, 0xd7, 0xd0, 0x79, 0x97, 0xe7, 0x0e, 0xf0,
+		0x5f, 0xdb, 0xdf, 0x65, 0xc1, 0x8a, 0x85, 0x48, 0xa9, 0x58, 0xf3, 0xae,
+		0x58, 0x85, 0xf2, 0x4b, 0x43, 0x55, 0xe9, 0x9e, 0x8b, 0xd4, 0x8d, 0x37,
+		0x3c, 0x0c, 0xd4, 0x5e, 0xa0, 0x11, 0xa7, 0xe7, 0x57, 0x5d, 0xf7, 0x50,
+		0x04, 0x72, 0x29, 0x16, 0x08, 0x4c, 0x81, 0x42, 0xae, 0x0d, 0x1e, 0x62,
+		0x81, 0x9a, 0xaf, 0x4c, 0x86, 0xe1, 0x85, 0x37, 0x53, 0x67, 0xf5, 0x2e,
+		0x48, 0x29, 0xc8, 0x29, 0xe3, 0x9f, 0x5b, 0x53, 0x8a, 0x2b, 0xba, 0x28,
+		0xa5, 0x78, 0xb5, 0x53, 0x5f, 0xff, 0xde, 0x3b, 0x9b, 0x46, 0x2e, 0x09,
+		0xd5, 0x3a, 0xc6, 0xfd, 0x27, 0xc2, 0xb4, 0xe9, 0x1a, 0xe1, 0xd4, 0x6e,
+		0x1d, 0x68, 0x91, 0x22, 0x0d, 0x47, 0xbc, 0xfd, 0x87, 0x50, 0x91, 0x52,
+		0x75, 0x8e, 0xe4, 0x05, 0x52, 0x8f, 0xbc, 0x99, 0xda, 0xf7, 0x67, 0xe8,
+		0x72, 0x5d, 0x37, 0x5a, 0x97, 0xd2, 0x1e, 0x01, 0x78, 0xee, 0x94, 0x7b,
+		0x60, 0x7e, 0x6d, 0x0c, 0x5b, 0x58, 0x0f, 0x43, 0x1e, 0x6e, 0x27, 0xee,
+		0x13, 0xbe, 0x6b, 0x60, 0x38, 0xd3, 0xac, 0xbb, 0xd9, 0x9d, 0x4c, 0xe8,
+		0x97, 0x8a, 0x3d, 0x0e, 0xbc, 0x78, 0x40, 0xb9, 0x6c, 0xd9, 0x01, 0x7e,
+		0x4a, 0x90, 0xc3, 0xd2, 0x5e, 0x20, 0x9c, 0x38, 0x97, 0x57, 0xb5, 0x2d,
+		0xa8, 0x23, 0xad, 0xa3, 0x73, 0xb9, 0xc4, 0x3f, 0x31, 0xd6, 0xd1, 0xec,
+		0xde, 0xfe, 0x0f, 0x1c, 0xd7, 0xae, 0xed, 0x67, 0x2f, 0x41, 0xa8, 0x14,
+		0xf9, 0xd0, 0xf0, 0x56, 0xba, 0xeb, 0x2e, 0xe4, 0x9d, 0xf7, 0x0e, 0x0d,
+		0xe3, 0x96, 0xb3, 0xf9, 0xad, 0x14, 0x39, 0xb8, 0xe2, 0x76, 0xef, 0x2d,
+		0x07, 0x74, 0xdf, 0x60, 0xc2, 0xd5, 0x1a, 0xa5, 0xda, 0x37, 0x1c, 0xd6,
+		0x4c, 0x27, 0xf0, 0xe2, 0xd9, 0xab, 0x01, 0xd0, 0x9a, 0x6c, 0x46, 0x56,
+		0xa8, 0x40, 0x0a, 0x91, 0xd9, 0x90, 0xf4, 0x3f, 0x56, 0xba, 0xbb, 0x3f,
+		0xfb, 0x63, 0x85, 0x61, 0xdb, 0x8a, 0x6b, 0xcf, 0x79, 0xc3, 0x9f, 0x4c,
+		0x39, 0x9c, 0x5b, 0x70, 0xe3, 0x04, 0xe3, 0xd5, 0x42, 0x6c, 0x9a, 0x77,
+		0xb5, 0x56, 0x7b, 0x6e, 0xb5, 0x6b, 0xb7, 0x8d, 0x35, 0x8f, 0x01, 0xb9,
+		0x96, 0x05, 0x3a, 0xd6, 0x6d, 0xd7, 0x79, 0xb6, 0x09, 0x6f, 0xec, 0x7a,
+		0x0c, 0xd6, 0xd1, 0x9e, 0x6f, 0x5b, 0x5b, 0x12, 0x46, 0x29, 0xf2, 0xee,
+		0x96, 0x78, 0x9f, 0x4b, 0x92, 0x36, 0x8f, 0x3d, 0xc6, 0x83, 0x1b, 0xf8,
+		0x89, 0x48, 0x43, 0x9e, 0x59, 0x9b, 0xb1, 0x59, 0xad, 0xa6, 0x21, 0x26,
+		0x2f, 0x9e, 0xbd, 0x02, 0x21, 0xe1, 0x87, 0xab, 0xe7, 0x2e, 0x28, 0x35,
+		0x54, 0xe7, 0xd8, 0x2f, 0x0a, 0xad, 0x1b, 0x37, 0x22, 0xee, 0x85, 0x6f,
+		0xbb, 0x2a, 0x16, 0xf6, 0xda, 0x3e, 0x18, 0x6b, 0x1e, 0xcd, 0x3e, 0x90,
+		0x07, 0x9c, 0x8c, 0x9d, 0x59, 0x7b, 0xad, 0x98, 0xf0, 0x18, 0xd3, 0x7a,
+		0x2d, 0x08, 0xaf, 0x04, 0x8f, 0x53, 0x16, 0xaf, 0xa6, 0x91, 0xfb, 0xf6,
+		0x39, 0x4a, 0x45, 0x4c, 0xec, 0x8f, 0xb2, 0xbe, 0x69, 0xfd, 0x51, 0xd6,
+		0x37, 0xd1, 0xec, 0xc6, 0xd6, 0x6c, 0x3a, 0xac, 0xfa, 0x32, 0x19, 0x9b,
+		0x5f, 0x76, 0xcd, 0x9e, 0xf8, 0x17, 0xe1, 0xb2, 0xe9, 0xaf, 0x01, 0x00,
+		0xf2, 0x07, 0x3d, 0x05, 0xad, 0x26, 0x00, 0x00,
+	},
+		"template/queue_edit.html",
+	)
+}
+
 func template_queue_view_html() ([]byte, error) {
 	return bindata_read([]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x00, 0xff, 0xc4, 0x57,
-		0xcd, 0x6e, 0xe3, 0x38, 0x0c, 0xbe, 0xf7, 0x29, 0x04, 0x03, 0x7b, 0x74,
-		0xdc, 0x02, 0xdb, 0x43, 0x07, 0xae, 0x81, 0x22, 0xed, 0x2c, 0x82, 0xdd,
-		0x69, 0x3b, 0xb1, 0xfb, 0x00, 0x8a, 0xcd, 0xc4, 0xc2, 0xb8, 0x76, 0x46,
-		0x92, 0xd3, 0x0d, 0x0c, 0xbf, 0xfb, 0xd2, 0xf2, 0x9f, 0xfc, 0x97, 0x38,
-		0xdd, 0x1d, 0xec, 0xa1, 0x68, 0x2c, 0x52, 0x1f, 0xf9, 0x91, 0x94, 0x48,
-		0x65, 0x59, 0x00, 0x5b, 0x16, 0x03, 0x31, 0xde, 0x29, 0x8b, 0x8d, 0x3c,
-		0xbf, 0xb2, 0x03, 0x76, 0x20, 0x7e, 0x44, 0x85, 0xb8, 0x37, 0xfc, 0x24,
-		0x96, 0x10, 0x4b, 0x72, 0x60, 0xf0, 0x61, 0xfe, 0x4c, 0x21, 0x05, 0xc3,
-		0xb9, 0x22, 0xc4, 0x0e, 0x6f, 0x6a, 0x0d, 0xc9, 0x64, 0x84, 0x8b, 0x36,
-		0x25, 0x21, 0x87, 0xed, 0xbd, 0x61, 0x29, 0x2d, 0x2b, 0xcb, 0x16, 0x6b,
-		0x10, 0x69, 0x24, 0x17, 0xdf, 0x17, 0xab, 0xc7, 0x3c, 0x37, 0x9c, 0xef,
-		0xc5, 0xfa, 0x17, 0xd2, 0x17, 0xd8, 0x16, 0x75, 0x6c, 0x2b, 0xbc, 0x51,
-		0xb0, 0x9a, 0x65, 0x01, 0xbe, 0x64, 0x49, 0xac, 0xcc, 0xf5, 0x25, 0x80,
-		0xcb, 0xb6, 0xd8, 0xd3, 0xd8, 0x69, 0xd1, 0x5c, 0x49, 0xa5, 0x58, 0x78,
-		0x89, 0xa4, 0xd1, 0x1a, 0x7c, 0x60, 0x07, 0x08, 0x0a, 0x70, 0xa5, 0x45,
-		0x24, 0x15, 0x3f, 0x04, 0xe1, 0xd5, 0xba, 0x6d, 0x21, 0xda, 0x10, 0xd7,
-		0x0f, 0x29, 0x97, 0xe6, 0x07, 0xa7, 0xfb, 0x3d, 0x70, 0x52, 0x7e, 0x09,
-		0x44, 0x4d, 0x45, 0xe5, 0xc4, 0xa8, 0x83, 0x66, 0x44, 0x37, 0x10, 0x19,
-		0x8e, 0xeb, 0x3d, 0x78, 0x6f, 0xae, 0x06, 0x3d, 0x02, 0xde, 0xe0, 0x74,
-		0x65, 0x1b, 0xca, 0x6b, 0xb3, 0x9a, 0x46, 0x57, 0x87, 0xc5, 0x65, 0xf8,
-		0x09, 0x2a, 0x17, 0x7f, 0x66, 0x04, 0x5b, 0x69, 0x10, 0x21, 0x8f, 0x11,
-		0xdc, 0x1b, 0x1f, 0x2c, 0x90, 0xe1, 0x97, 0x36, 0x18, 0xcb, 0xc2, 0xda,
-		0x2a, 0x56, 0x21, 0x7f, 0xf5, 0x65, 0x9e, 0xff, 0x86, 0x01, 0xd3, 0x5d,
-		0x1b, 0x90, 0xf1, 0x43, 0x08, 0xd2, 0x08, 0x82, 0x06, 0x9f, 0xb3, 0x5d,
-		0x78, 0xc6, 0x80, 0x5b, 0x6f, 0x9a, 0x30, 0xd1, 0xff, 0x94, 0x74, 0x13,
-		0x41, 0x6d, 0xb1, 0x0a, 0x9b, 0xee, 0x8f, 0xe4, 0xfa, 0x67, 0xb1, 0x10,
-		0x10, 0x65, 0xf8, 0xde, 0xf8, 0xfd, 0x16, 0xe1, 0xfb, 0xc9, 0xae, 0x08,
-		0xe6, 0x39, 0x61, 0x31, 0x51, 0xd1, 0xb1, 0x2d, 0x19, 0x4c, 0x62, 0xdc,
-		0x5c, 0x23, 0x86, 0x49, 0x2c, 0x62, 0x9e, 0x54, 0x9b, 0x30, 0xd5, 0x90,
-		0x45, 0x73, 0x4d, 0xb4, 0xfa, 0x40, 0xf8, 0xcd, 0x75, 0xfe, 0x8a, 0x71,
-		0x53, 0x0e, 0x5a, 0xd9, 0xcd, 0xaf, 0x40, 0xae, 0xdc, 0x98, 0x55, 0x82,
-		0xeb, 0x27, 0xf7, 0xed, 0x2f, 0xef, 0xd7, 0xd5, 0xe0, 0x9e, 0x27, 0x3e,
-		0x08, 0x01, 0x81, 0x99, 0xfc, 0xb8, 0xa0, 0x0e, 0xdd, 0xd4, 0xf7, 0x01,
-		0x82, 0xc9, 0x32, 0x99, 0x32, 0x02, 0x9c, 0x27, 0xfc, 0x92, 0x7a, 0x7c,
-		0x2a, 0x36, 0xfc, 0x4f, 0xb5, 0xa8, 0x2e, 0x9e, 0xd7, 0xda, 0xf7, 0x97,
-		0x3f, 0x8b, 0x2a, 0xa9, 0x79, 0xff, 0xb2, 0xaa, 0xec, 0x1a, 0x55, 0xf4,
-		0xd1, 0xae, 0x8a, 0xdb, 0xbf, 0xae, 0xcc, 0xf6, 0x47, 0xb7, 0x1d, 0x6c,
-		0xd9, 0x8e, 0x9c, 0xba, 0x9b, 0x3b, 0x15, 0xb9, 0x7c, 0x79, 0xfe, 0xba,
-		0xfa, 0xe3, 0x6d, 0xfd, 0xe0, 0xad, 0x5e, 0x9e, 0x75, 0x2b, 0x5d, 0xf3,
-		0x1d, 0xd7, 0x64, 0xe8, 0x7c, 0xa3, 0x7f, 0x13, 0x4e, 0x65, 0x71, 0x9c,
-		0xc3, 0xa1, 0x04, 0x9d, 0xf0, 0x53, 0xce, 0xb1, 0x2d, 0x8d, 0xcb, 0x25,
-		0x67, 0x20, 0x86, 0x22, 0x0f, 0x7b, 0x00, 0x91, 0xec, 0x1d, 0x92, 0xb4,
-		0xb3, 0x51, 0x8f, 0x4c, 0xcf, 0x93, 0xc0, 0xd1, 0xbb, 0xd5, 0x52, 0x91,
-		0x5f, 0xa0, 0x89, 0x35, 0xfa, 0x86, 0xcd, 0xa5, 0xe8, 0x2d, 0x35, 0xf1,
-		0x34, 0x66, 0x78, 0xb4, 0x2c, 0x51, 0x75, 0x9c, 0x6e, 0xf8, 0xa7, 0x91,
-		0x96, 0x0d, 0x97, 0xa2, 0x59, 0xcd, 0xd8, 0x53, 0xb0, 0xc0, 0x7d, 0x5e,
-		0xc1, 0xf1, 0x82, 0x2d, 0x5e, 0xc9, 0x7b, 0xbe, 0xd3, 0x6d, 0x54, 0xb4,
-		0x5a, 0x19, 0xad, 0x09, 0xbc, 0x31, 0xb6, 0x09, 0x7f, 0xa7, 0xb1, 0x0f,
-		0xf3, 0x0b, 0xe3, 0xf5, 0x69, 0xfd, 0xf5, 0x65, 0xfd, 0xed, 0xe1, 0x79,
-		0xf9, 0xd4, 0xbb, 0x0b, 0xdb, 0x54, 0xe8, 0x25, 0x32, 0x38, 0x91, 0x45,
-		0x46, 0xbb, 0x39, 0x2e, 0xd7, 0x6e, 0xaf, 0x65, 0x48, 0xd0, 0x25, 0x1f,
-		0x43, 0xca, 0x22, 0x18, 0x53, 0xb9, 0x9b, 0xa1, 0x72, 0x77, 0x42, 0xa5,
-		0x77, 0x94, 0xfa, 0x25, 0xd3, 0x61, 0x9d, 0x6e, 0xcc, 0x1e, 0xf3, 0x87,
-		0x20, 0x60, 0xf1, 0xae, 0x24, 0x3d, 0x4c, 0x5f, 0x27, 0x3d, 0x07, 0x1a,
-		0x15, 0xf3, 0x56, 0x9b, 0x52, 0xdc, 0xfb, 0x8a, 0x4e, 0xdd, 0x5e, 0x37,
-		0x93, 0xcd, 0xbb, 0xf8, 0x24, 0xc6, 0xdd, 0x7f, 0x81, 0x71, 0x37, 0x81,
-		0xd1, 0x39, 0x52, 0xfa, 0x55, 0xf3, 0xd9, 0x5c, 0x9f, 0x8d, 0x6a, 0x75,
-		0x09, 0x4e, 0x44, 0x76, 0x0e, 0xa7, 0x16, 0xe1, 0x4c, 0x88, 0x2f, 0x07,
-		0x3b, 0x11, 0xeb, 0x4f, 0x80, 0x4d, 0x05, 0x7d, 0x4e, 0xd8, 0x47, 0x0f,
-		0x70, 0x39, 0x1c, 0xb7, 0xc3, 0x7d, 0x5f, 0xba, 0xd1, 0x86, 0x8f, 0x73,
-		0x53, 0x7e, 0x96, 0xb1, 0x2d, 0x81, 0x9f, 0xa4, 0x5e, 0xf6, 0x8e, 0x7b,
-		0x7c, 0x59, 0xe0, 0xab, 0xa2, 0x86, 0xa3, 0x98, 0xb6, 0x03, 0xa0, 0x1e,
-		0xc4, 0x38, 0x49, 0x39, 0xab, 0x66, 0x70, 0xa3, 0x73, 0x6d, 0x58, 0xcd,
-		0xf4, 0x35, 0x61, 0xad, 0x95, 0x4f, 0x9a, 0x75, 0xdb, 0x01, 0x8e, 0x8e,
-		0x4e, 0x4a, 0x11, 0x50, 0xde, 0x99, 0x21, 0x4e, 0x34, 0xaf, 0x10, 0x68,
-		0x70, 0xf2, 0x9e, 0x52, 0x9d, 0x67, 0xf5, 0x38, 0x76, 0xd1, 0x78, 0x94,
-		0xef, 0x40, 0x8e, 0x4a, 0x86, 0x5d, 0xac, 0x14, 0x3c, 0x42, 0x44, 0x8f,
-		0xd3, 0x37, 0x52, 0x21, 0xd1, 0x1c, 0xb2, 0xe5, 0x26, 0x09, 0x8e, 0xf5,
-		0x57, 0x96, 0x61, 0x5f, 0x8d, 0x77, 0xd0, 0x46, 0x4c, 0xe5, 0x1e, 0x1f,
-		0x7d, 0x27, 0x8e, 0x5e, 0x96, 0xb9, 0x61, 0x82, 0xcf, 0x89, 0x47, 0x52,
-		0x3d, 0xd8, 0x86, 0x05, 0x8c, 0x39, 0x2a, 0x99, 0x4c, 0x8b, 0xc7, 0x1a,
-		0x56, 0x23, 0x55, 0x9c, 0x06, 0xed, 0x4c, 0x63, 0x85, 0x8e, 0x63, 0xe6,
-		0x5a, 0x47, 0x51, 0xd6, 0xf2, 0x1a, 0x76, 0xa8, 0xea, 0x5f, 0x95, 0xee,
-		0xab, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x6a, 0x4e, 0x83, 0x0a, 0xe4,
-		0x0e, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x59,
+		0xdd, 0x6e, 0xdb, 0x3a, 0x12, 0xbe, 0xef, 0x53, 0x0c, 0x84, 0x14, 0x68,
+		0x81, 0x38, 0x6e, 0x77, 0xb7, 0x17, 0x29, 0x1c, 0x01, 0xf9, 0xeb, 0xd6,
+		0x7b, 0x4e, 0x1a, 0x1f, 0xdb, 0x7d, 0x00, 0xda, 0x1c, 0x5b, 0xdc, 0xd0,
+		0xa4, 0x4a, 0x52, 0x71, 0x0c, 0x41, 0xef, 0xbe, 0x20, 0x29, 0xc9, 0x92,
+		0x2c, 0xc9, 0x4e, 0x7b, 0x8a, 0xbd, 0x08, 0x22, 0x93, 0xc3, 0xf9, 0xfd,
+		0x38, 0x33, 0x24, 0xd3, 0x94, 0xe2, 0x8a, 0x09, 0x84, 0x60, 0x43, 0x98,
+		0x08, 0xb2, 0xec, 0xcd, 0x88, 0xb2, 0x67, 0x58, 0x72, 0xa2, 0xf5, 0x55,
+		0xb0, 0x94, 0xc2, 0xa0, 0x30, 0xf0, 0xcc, 0x70, 0x3b, 0xf8, 0x91, 0x60,
+		0x82, 0x41, 0xf8, 0x06, 0x60, 0x14, 0x7d, 0x2c, 0x28, 0x0c, 0x33, 0x1c,
+		0x83, 0x70, 0x44, 0x20, 0x52, 0xb8, 0xba, 0x0a, 0x86, 0x8e, 0x6a, 0x98,
+		0xa6, 0x17, 0x53, 0xd4, 0x09, 0x37, 0x17, 0x7f, 0x5d, 0x8c, 0xef, 0xb2,
+		0x2c, 0x08, 0xff, 0xb2, 0xe3, 0x9f, 0xa1, 0x39, 0x31, 0x1a, 0x92, 0x70,
+		0x34, 0x8c, 0x3e, 0x3a, 0xb6, 0x15, 0xc9, 0x1a, 0x97, 0x86, 0x49, 0xe1,
+		0xc4, 0x35, 0x67, 0x50, 0x04, 0xe1, 0x48, 0xc7, 0x44, 0x84, 0x7b, 0x6e,
+		0x33, 0x43, 0x8c, 0xbe, 0x98, 0x4b, 0x43, 0xf8, 0x14, 0x97, 0xc8, 0x9e,
+		0x91, 0x5a, 0xe6, 0x8e, 0x0a, 0x0c, 0xd1, 0x4f, 0x1a, 0x54, 0x3e, 0x3e,
+		0x1a, 0x52, 0xf6, 0x9c, 0xf3, 0x5d, 0x49, 0xb5, 0x29, 0x18, 0xc7, 0x24,
+		0xd1, 0x18, 0xc0, 0x06, 0x4d, 0x24, 0xe9, 0x55, 0x10, 0x4b, 0x6d, 0x02,
+		0x20, 0x4e, 0x8d, 0x4e, 0xbb, 0x86, 0x69, 0xca, 0x56, 0x50, 0x57, 0x62,
+		0x62, 0xf9, 0xd0, 0x2c, 0x53, 0xa8, 0x93, 0x0d, 0xa6, 0x29, 0x72, 0x8d,
+		0x59, 0xe6, 0xb8, 0xa7, 0x29, 0x0a, 0x6a, 0xbd, 0xe1, 0xa4, 0x03, 0xf4,
+		0xac, 0xce, 0x29, 0xbc, 0x9d, 0x7e, 0xf0, 0x1c, 0x84, 0xcc, 0x6d, 0x21,
+		0x0a, 0x81, 0x32, 0x1d, 0x13, 0xb3, 0x8c, 0xac, 0x45, 0x8e, 0xaa, 0x58,
+		0xb2, 0x48, 0x8c, 0x91, 0x02, 0xcc, 0x2e, 0xc6, 0xab, 0x40, 0x27, 0x8b,
+		0x0d, 0x33, 0x41, 0x38, 0x75, 0xda, 0x8c, 0x86, 0x7e, 0x72, 0xaf, 0x80,
+		0xd7, 0xae, 0x77, 0xa9, 0x93, 0xde, 0xb2, 0x52, 0x54, 0xd4, 0x24, 0x85,
+		0x17, 0x91, 0x32, 0x13, 0xf4, 0x83, 0x61, 0xe8, 0x68, 0xc2, 0x7b, 0xca,
+		0x0c, 0x68, 0x34, 0x86, 0x89, 0xb5, 0xb6, 0x38, 0xf0, 0x21, 0x19, 0xda,
+		0x98, 0x84, 0x6f, 0x3a, 0xdc, 0x33, 0x25, 0x06, 0xff, 0x64, 0x1b, 0x66,
+		0x2e, 0xe6, 0x91, 0x92, 0xc6, 0xf0, 0xd2, 0x57, 0x55, 0x8c, 0x44, 0xc8,
+		0x69, 0x10, 0x96, 0x14, 0xb0, 0xd8, 0x81, 0x89, 0x10, 0x14, 0x31, 0x08,
+		0xdc, 0xae, 0x06, 0xb9, 0x82, 0x34, 0xb5, 0xcc, 0x60, 0xaf, 0xdb, 0xad,
+		0x14, 0x2b, 0xb6, 0xbe, 0x78, 0x20, 0x2f, 0xed, 0x13, 0x76, 0x74, 0x82,
+		0x8a, 0x49, 0x07, 0xad, 0x12, 0x44, 0x55, 0x4f, 0xb4, 0x68, 0xfc, 0x15,
+		0x39, 0xfd, 0x22, 0x55, 0xa7, 0x96, 0x77, 0x79, 0x18, 0xc1, 0xfe, 0x84,
+		0x95, 0x54, 0x90, 0xa6, 0x1d, 0x1c, 0xf4, 0x39, 0x18, 0xa2, 0xd6, 0x68,
+		0x80, 0xe8, 0x27, 0xa4, 0x60, 0x24, 0x28, 0x34, 0x6a, 0x07, 0x9c, 0x18,
+		0x54, 0x1d, 0x2a, 0xd5, 0xf6, 0x73, 0x44, 0x94, 0x19, 0x6c, 0x15, 0x89,
+		0x63, 0x54, 0xe0, 0x7f, 0x69, 0x43, 0x4c, 0xa2, 0x4b, 0x48, 0xb6, 0x6c,
+		0xc2, 0x01, 0x27, 0x0b, 0xe4, 0x41, 0x38, 0x9b, 0x5f, 0xcf, 0xbf, 0xcf,
+		0x2a, 0x62, 0x5a, 0x98, 0x97, 0x7c, 0xea, 0x73, 0x0b, 0xa2, 0x0a, 0xb1,
+		0x15, 0x8a, 0x3a, 0x0d, 0x13, 0x3e, 0xc5, 0xc0, 0x82, 0x28, 0xfb, 0x37,
+		0xe0, 0xb8, 0x32, 0x01, 0x68, 0xb3, 0xe3, 0x78, 0x15, 0x6c, 0x19, 0x35,
+		0xd1, 0xe7, 0xbd, 0x6b, 0x6e, 0xad, 0xb4, 0xb1, 0x70, 0x69, 0x65, 0xb2,
+		0x34, 0x59, 0xf6, 0x36, 0x08, 0x6b, 0xaa, 0x1d, 0x18, 0x63, 0x77, 0x4a,
+		0xe2, 0xd0, 0x90, 0xf3, 0x57, 0x6c, 0x1d, 0x1d, 0x11, 0x30, 0x2b, 0x16,
+		0x75, 0x88, 0x68, 0xfe, 0x34, 0x64, 0xc1, 0xb1, 0x90, 0x98, 0xbb, 0xad,
+		0xaa, 0x8f, 0x51, 0xd5, 0x9f, 0x76, 0x80, 0x82, 0x13, 0x7c, 0x15, 0xfc,
+		0xeb, 0xd3, 0xdb, 0xe0, 0x20, 0xa1, 0xe5, 0x06, 0x66, 0x19, 0x30, 0x01,
+		0xce, 0x3b, 0xa3, 0xa1, 0xa1, 0x9d, 0x3c, 0x3e, 0x7e, 0x78, 0x1b, 0x84,
+		0x03, 0x18, 0xc2, 0xa0, 0x97, 0xac, 0x43, 0x54, 0x69, 0x6c, 0x96, 0x41,
+		0xe9, 0xad, 0x26, 0xa3, 0xd1, 0xb0, 0x6a, 0xc3, 0x68, 0xe8, 0x2c, 0x0e,
+		0xdf, 0x1c, 0xb8, 0xa3, 0xfa, 0x79, 0x04, 0x81, 0xca, 0xa9, 0x71, 0x12,
+		0x04, 0xa7, 0xf7, 0xb3, 0xef, 0x7f, 0xce, 0x7f, 0x1f, 0x06, 0x63, 0x25,
+		0x97, 0xa8, 0x35, 0xd2, 0x81, 0x7c, 0x7a, 0x05, 0x0e, 0x67, 0xc9, 0x72,
+		0x89, 0x48, 0x3b, 0x61, 0xd2, 0x25, 0x04, 0x95, 0x92, 0xea, 0x35, 0x78,
+		0xbc, 0xb7, 0x0b, 0xfe, 0x4f, 0x58, 0x74, 0xc5, 0x75, 0x52, 0xe8, 0xfe,
+		0xf8, 0x87, 0x45, 0x49, 0x61, 0xf7, 0x6f, 0x43, 0x65, 0x5d, 0xa8, 0x33,
+		0x3f, 0xcb, 0xc0, 0xf9, 0xad, 0x25, 0xd7, 0x3a, 0xea, 0xfb, 0x97, 0x98,
+		0x29, 0x0b, 0xe2, 0x73, 0x48, 0xd3, 0xbe, 0x79, 0x40, 0xff, 0x95, 0x67,
+		0xcb, 0x5f, 0x46, 0xfa, 0xfe, 0xa3, 0xde, 0x42, 0xad, 0xd8, 0x1a, 0xfa,
+		0xfa, 0x99, 0x1a, 0xc2, 0x6f, 0x1f, 0xbf, 0x7d, 0x19, 0xff, 0xfb, 0xfb,
+		0xf4, 0x7a, 0x3e, 0x7e, 0xfc, 0x56, 0x95, 0x52, 0x17, 0x5f, 0x53, 0xcd,
+		0x44, 0xe1, 0x03, 0x79, 0x71, 0xe5, 0x6d, 0x34, 0x34, 0x51, 0x7d, 0xe6,
+		0x26, 0x51, 0xda, 0x1c, 0x0e, 0xdb, 0x05, 0x4b, 0x29, 0x96, 0x89, 0x52,
+		0x28, 0x3a, 0xe6, 0x8d, 0x62, 0xa8, 0x0f, 0xa7, 0xe6, 0x44, 0x3f, 0x81,
+		0x61, 0x1b, 0x94, 0x49, 0x6d, 0x61, 0xd5, 0x61, 0x0d, 0x05, 0x69, 0x58,
+		0x8b, 0x56, 0xb3, 0xd6, 0x66, 0xd9, 0x2f, 0x15, 0xe3, 0xa2, 0x8b, 0x19,
+		0xb4, 0x46, 0xd2, 0x4b, 0xef, 0x6a, 0x23, 0x9c, 0x7b, 0xfa, 0x97, 0x54,
+		0x95, 0xb9, 0x2d, 0x5d, 0x76, 0xe2, 0x1a, 0xeb, 0xac, 0x07, 0xf2, 0x32,
+		0xb7, 0xae, 0x7c, 0xc5, 0x92, 0xb9, 0x77, 0x6f, 0x96, 0xb9, 0x06, 0xb0,
+		0xc0, 0x4a, 0x22, 0x6c, 0xe3, 0x34, 0xd4, 0x79, 0xc3, 0x57, 0xe5, 0xb6,
+		0x77, 0x7e, 0x0d, 0xa9, 0xce, 0xed, 0xf8, 0xa3, 0xc5, 0xb1, 0x92, 0x22,
+		0x04, 0x71, 0xc2, 0x79, 0xd0, 0xd2, 0x28, 0x68, 0xb6, 0x16, 0x4c, 0xac,
+		0x83, 0x70, 0x92, 0x70, 0x0e, 0x1b, 0x49, 0xf1, 0xdc, 0xa2, 0xc5, 0xb6,
+		0x90, 0x4a, 0x03, 0x47, 0xa2, 0x31, 0xef, 0x44, 0x4d, 0xa4, 0x64, 0xb2,
+		0x8e, 0x5c, 0x7f, 0x75, 0x3d, 0x19, 0x9f, 0xd2, 0x17, 0x95, 0x3a, 0xcc,
+		0xbc, 0x94, 0x19, 0x2e, 0x15, 0x1a, 0xdd, 0xa7, 0xc6, 0x14, 0x7f, 0x24,
+		0xa8, 0x8d, 0x6f, 0x7c, 0xed, 0x28, 0xda, 0xb4, 0x61, 0x22, 0x48, 0x53,
+		0x8e, 0xe2, 0x38, 0x67, 0xbb, 0xff, 0x14, 0x9a, 0x77, 0xfa, 0xfd, 0xab,
+		0x14, 0xbc, 0x51, 0x48, 0x9e, 0x50, 0xcd, 0x23, 0x85, 0x3a, 0x92, 0x9c,
+		0xf6, 0xa9, 0x58, 0xf6, 0x71, 0x46, 0x02, 0x29, 0xfa, 0xb4, 0x48, 0x6a,
+		0x03, 0xda, 0xc8, 0x58, 0x03, 0x59, 0x19, 0x54, 0x90, 0xa6, 0x27, 0x08,
+		0x71, 0xae, 0xc6, 0x65, 0x62, 0xd8, 0x33, 0xc2, 0x8a, 0x30, 0x9e, 0x28,
+		0xd4, 0xe7, 0x10, 0x2b, 0xb9, 0x40, 0x0a, 0x64, 0x4d, 0x98, 0x28, 0xd9,
+		0xf5, 0xa8, 0x7d, 0x2b, 0x25, 0xa7, 0x72, 0x2b, 0xec, 0x06, 0x39, 0x85,
+		0xc6, 0x6f, 0xa2, 0x7f, 0x7e, 0xc8, 0xfd, 0xa2, 0xfb, 0x5c, 0x25, 0x55,
+		0xeb, 0x5e, 0x9d, 0xa0, 0xa0, 0x4c, 0xac, 0xfb, 0xe6, 0x6e, 0x76, 0x06,
+		0x7b, 0x63, 0xfd, 0x55, 0x72, 0xaa, 0x81, 0x18, 0xd8, 0x58, 0xe7, 0x75,
+		0xe6, 0x8d, 0x9c, 0x5d, 0xab, 0x71, 0xd5, 0x69, 0x87, 0xd3, 0x77, 0xfa,
+		0x7d, 0x6e, 0x82, 0xe3, 0x47, 0x04, 0xfd, 0x45, 0xf5, 0x1d, 0x8b, 0x2a,
+		0xcb, 0xe3, 0x4b, 0xd2, 0xf4, 0x38, 0x0d, 0xc4, 0x64, 0xc7, 0x25, 0xb1,
+		0x27, 0x16, 0x83, 0x3a, 0xe7, 0x7f, 0xde, 0xbd, 0x8b, 0x1f, 0x9f, 0x51,
+		0xad, 0xb8, 0xdc, 0x42, 0x40, 0x95, 0x8c, 0x07, 0x92, 0x53, 0xd4, 0x26,
+		0xc8, 0x32, 0xfb, 0x2b, 0xb6, 0x96, 0xd8, 0x6d, 0xe9, 0x47, 0x8b, 0xf8,
+		0x2a, 0xfc, 0xaf, 0xad, 0x38, 0x62, 0x0d, 0x02, 0xb7, 0x7e, 0x13, 0xe7,
+		0x72, 0x60, 0x1b, 0xa1, 0x80, 0x95, 0xdd, 0xf1, 0xef, 0x3a, 0x4e, 0xd6,
+		0x76, 0xa9, 0x2b, 0x9d, 0x2a, 0xff, 0xec, 0xa8, 0xb1, 0x77, 0x56, 0xbe,
+		0x23, 0xa4, 0xfe, 0xab, 0x7b, 0xdf, 0xb9, 0x6d, 0x7c, 0x98, 0xde, 0xed,
+		0xb9, 0xa6, 0x40, 0x49, 0x7f, 0xe9, 0x73, 0xa4, 0xb0, 0x20, 0xcb, 0x27,
+		0xb9, 0x5a, 0x1d, 0x56, 0xab, 0xb1, 0x60, 0x86, 0x11, 0x0e, 0x14, 0x39,
+		0xd9, 0xb5, 0xd7, 0xb9, 0x8e, 0xa9, 0xff, 0x30, 0xe3, 0x8e, 0x55, 0xaf,
+		0xaa, 0x70, 0x37, 0x5e, 0x0f, 0x17, 0xee, 0xca, 0xb7, 0xf7, 0x3d, 0xbe,
+		0xc4, 0x52, 0xa0, 0xb0, 0xfa, 0x54, 0xb1, 0x23, 0x15, 0xbc, 0xb3, 0xd1,
+		0xcd, 0xe9, 0x21, 0x08, 0xde, 0x37, 0x06, 0x2a, 0x0b, 0x83, 0xf7, 0x59,
+		0x06, 0x2f, 0x5e, 0xd8, 0x43, 0xc2, 0x0d, 0x8b, 0x39, 0x43, 0xe5, 0xe4,
+		0xd5, 0x7f, 0x7a, 0x91, 0xff, 0x28, 0x05, 0x75, 0x96, 0x46, 0xcb, 0x29,
+		0xf7, 0xd2, 0x9d, 0xf5, 0x84, 0xe3, 0xd5, 0x1c, 0xa8, 0x73, 0x6b, 0xa9,
+		0x4a, 0x6d, 0x45, 0xa9, 0x2a, 0xe1, 0x81, 0xbc, 0xec, 0xb9, 0xef, 0x7f,
+		0xf4, 0x70, 0x3a, 0xa5, 0xac, 0x5b, 0xce, 0x3e, 0x4e, 0x8e, 0xef, 0xfe,
+		0xd3, 0x2f, 0x15, 0x52, 0x60, 0xcb, 0xea, 0xce, 0x62, 0x59, 0x00, 0xb3,
+		0x44, 0x6b, 0x4b, 0x93, 0x99, 0x67, 0x4e, 0x9f, 0xc3, 0x6a, 0x07, 0x8c,
+		0x7c, 0xe2, 0x15, 0xcd, 0xde, 0x78, 0x7a, 0xfb, 0x7d, 0x3c, 0x87, 0x9b,
+		0xe9, 0xfd, 0xf5, 0x1f, 0xf7, 0xd3, 0xd9, 0xe9, 0xfd, 0xde, 0x57, 0xd9,
+		0xd6, 0xd4, 0xcd, 0x4c, 0x6b, 0x0b, 0xf8, 0x25, 0x2f, 0x21, 0x87, 0x33,
+		0x13, 0x5b, 0x52, 0x80, 0x89, 0x2e, 0x90, 0xa7, 0xa9, 0x22, 0x62, 0x8d,
+		0x7d, 0x1e, 0xe8, 0xd8, 0x0b, 0x17, 0x56, 0xc3, 0x96, 0x98, 0x35, 0x9c,
+		0x35, 0x48, 0x53, 0xc7, 0x14, 0xed, 0xad, 0xd8, 0xfe, 0xbb, 0xb5, 0x4f,
+		0x2a, 0xcc, 0xe8, 0x46, 0x82, 0x33, 0x67, 0xec, 0x0b, 0x5e, 0xf9, 0xfd,
+		0x93, 0x08, 0xab, 0xbb, 0xa1, 0x72, 0x9f, 0x72, 0xd0, 0x5e, 0xb5, 0xe5,
+		0x3f, 0x5c, 0xa1, 0x52, 0xd8, 0xdb, 0x30, 0xa4, 0x69, 0xef, 0xc2, 0xa2,
+		0x6e, 0x01, 0xcd, 0x87, 0x60, 0x1b, 0x31, 0x8e, 0x40, 0x20, 0x77, 0x1d,
+		0x6c, 0x89, 0x06, 0x19, 0xa3, 0x68, 0x4d, 0xad, 0x15, 0x04, 0x97, 0x43,
+		0xd5, 0x33, 0x28, 0x2a, 0x7b, 0xcf, 0x46, 0xc4, 0x12, 0x4f, 0x87, 0xeb,
+		0xe4, 0x7e, 0xfa, 0xe5, 0x71, 0xfa, 0x70, 0xfd, 0xed, 0xf6, 0xbe, 0x71,
+		0xbc, 0xdf, 0xe3, 0xb4, 0x8a, 0xda, 0x83, 0x43, 0xa6, 0x85, 0x5c, 0x1d,
+		0x84, 0x7e, 0xec, 0xd3, 0x07, 0x13, 0x41, 0x8c, 0x6a, 0x69, 0x53, 0x1c,
+		0xc7, 0x36, 0x92, 0xcb, 0x13, 0x48, 0x2e, 0x7b, 0x48, 0x1a, 0xa7, 0xb9,
+		0x26, 0x60, 0x6b, 0x56, 0x27, 0x8b, 0x41, 0xc3, 0xf2, 0x6b, 0x6a, 0x8b,
+		0xb4, 0x37, 0xfa, 0x10, 0x7c, 0x35, 0x84, 0x3d, 0x13, 0x9e, 0x60, 0x35,
+		0xba, 0xd7, 0x94, 0x4e, 0x50, 0x2d, 0x3f, 0x7d, 0x28, 0x2f, 0xa4, 0x37,
+		0xfa, 0x27, 0x79, 0x5c, 0xfe, 0x1d, 0x3c, 0x2e, 0x3b, 0x78, 0xd4, 0x8a,
+		0x5b, 0x15, 0xe4, 0x3f, 0x1b, 0xeb, 0xa3, 0x5e, 0xcd, 0xcf, 0xf5, 0x1d,
+		0x9e, 0x3d, 0xc5, 0xa6, 0x3d, 0x87, 0x23, 0x2e, 0x7e, 0x3d, 0xb3, 0xcb,
+		0xbf, 0x95, 0xd9, 0x65, 0x77, 0xe0, 0x8e, 0xba, 0xbd, 0xf5, 0x5e, 0xc1,
+		0x9f, 0xbe, 0xf6, 0x6f, 0x32, 0xcd, 0xd9, 0x45, 0xe5, 0x3e, 0xed, 0xd8,
+		0xe3, 0x4c, 0xb3, 0xbd, 0x9c, 0xef, 0x62, 0x84, 0x20, 0xc8, 0xb2, 0x82,
+		0x9d, 0x7d, 0x05, 0x79, 0xc6, 0x20, 0x4f, 0x25, 0xe1, 0xb8, 0xbc, 0x8b,
+		0x24, 0xa7, 0xca, 0x18, 0x96, 0x17, 0x8a, 0x1d, 0xd2, 0xf6, 0xf3, 0x9d,
+		0x62, 0x67, 0xfb, 0x3b, 0x49, 0x52, 0x7b, 0x41, 0xe9, 0x65, 0x56, 0x28,
+		0x58, 0x7d, 0xea, 0x51, 0x89, 0x18, 0x10, 0xce, 0x5f, 0xfb, 0xd8, 0x53,
+		0xb2, 0x1d, 0xaa, 0x44, 0x54, 0x6f, 0x19, 0xdb, 0x5f, 0x5c, 0x12, 0x01,
+		0x84, 0x73, 0x10, 0x72, 0xdb, 0x7c, 0x3c, 0xa9, 0x3e, 0x72, 0x1c, 0x3c,
+		0xa5, 0x54, 0x2f, 0x8f, 0x38, 0x12, 0x55, 0xbb, 0xe8, 0xeb, 0xe9, 0x10,
+		0x22, 0x24, 0xb4, 0x37, 0xf3, 0xba, 0x7b, 0x9b, 0xf1, 0x5d, 0x4b, 0xea,
+		0xdc, 0xdf, 0x4c, 0x32, 0xa9, 0x98, 0xd9, 0xd9, 0xad, 0xe9, 0xbf, 0x7a,
+		0x88, 0xbd, 0xef, 0x82, 0xf0, 0xc1, 0xfd, 0x6f, 0x21, 0x0c, 0xe7, 0xee,
+		0x10, 0xdc, 0x36, 0x73, 0x9b, 0x3f, 0x2c, 0x5a, 0x8f, 0xb5, 0xcd, 0x7f,
+		0x45, 0x42, 0x51, 0xe9, 0x56, 0xa6, 0x87, 0x97, 0x52, 0x7e, 0xe2, 0xee,
+		0xb0, 0x8b, 0x3f, 0x05, 0x1f, 0x15, 0x8b, 0x5c, 0x54, 0x1d, 0x87, 0x7a,
+		0x48, 0x0e, 0x36, 0x69, 0xd5, 0xd5, 0x23, 0xb3, 0x90, 0x74, 0xb7, 0x8f,
+		0x25, 0xd4, 0x3b, 0xa6, 0xb9, 0xdb, 0xa7, 0x55, 0x56, 0x2d, 0x69, 0xf2,
+		0x60, 0xf3, 0x9c, 0x35, 0x90, 0x67, 0x77, 0xbb, 0xc5, 0xa3, 0x7f, 0x4b,
+		0x4d, 0xd3, 0x59, 0x24, 0x95, 0x19, 0xdf, 0x41, 0xe5, 0x0d, 0xf5, 0x30,
+		0x41, 0xb9, 0x26, 0xc8, 0x87, 0x31, 0xcb, 0xda, 0x09, 0x5c, 0x63, 0xee,
+		0xe2, 0xe7, 0xdb, 0xf2, 0xf2, 0xd3, 0xf7, 0x45, 0x93, 0xc7, 0xd9, 0xbc,
+		0xeb, 0x76, 0xd4, 0x0b, 0xf0, 0x21, 0xee, 0x9c, 0xce, 0xe3, 0x6c, 0xbd,
+		0xde, 0x45, 0xe3, 0xdd, 0x75, 0xc6, 0xce, 0xe1, 0x4c, 0x90, 0x0d, 0xc2,
+		0xe7, 0x2b, 0xb8, 0xf0, 0xe1, 0xff, 0x46, 0x36, 0xfe, 0xdc, 0xcc, 0x56,
+		0x70, 0xc6, 0xfc, 0x39, 0x38, 0x3f, 0xcb, 0x38, 0xd2, 0x8e, 0x43, 0xcd,
+		0x5e, 0xb7, 0xb6, 0x3b, 0xb7, 0x72, 0xb6, 0x38, 0x7f, 0x34, 0x66, 0x0b,
+		0xc0, 0x9c, 0x1d, 0xcd, 0x28, 0x05, 0xaf, 0xca, 0xcf, 0x22, 0xcd, 0x1c,
+		0x49, 0x2b, 0x7d, 0xd1, 0x6d, 0xa4, 0x96, 0x63, 0x09, 0xa6, 0x25, 0xb9,
+		0xb4, 0xa5, 0x18, 0x3f, 0xd2, 0xb4, 0xb4, 0x1b, 0xe3, 0x69, 0x0a, 0x28,
+		0xe8, 0x1e, 0xb6, 0xa3, 0x61, 0x05, 0xe5, 0x95, 0x2a, 0x95, 0xa7, 0xa3,
+		0xfc, 0x5f, 0xc1, 0xf1, 0x7f, 0x03, 0x00, 0x2a, 0x71, 0xf6, 0x09, 0x55,
+		0x20, 0x00, 0x00,
 	},
 		"template/queue_view.html",
 	)
 }
 
+func template_task_view_html() ([]byte, error) {
+	return bindata_read([]byte{
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x00, 0xff, 0xa5, 0x55,
+		0xdb, 0x6e, 0xdb, 0x30, 0x0c, 0x7d, 0xef, 0x57, 0x08, 0x46, 0x1e, 0x36,
+		0xa0, 0xb1, 0x93, 0x62, 0xdb, 0x43, 0xe0, 0x1a, 0x08, 0x96, 0x0c, 0x0b,
+		0xb6, 0x76, 0xe9, 0xe2, 0x7d, 0x80, 0x1a, 0xb3, 0xb5, 0x50, 0xc7, 0xf6,
+		0x24, 0xa5, 0x6d, 0x60, 0xf8, 0xdf, 0xa7, 0xab, 0x6f, 0x51, 0x52, 0x60,
+		0x7d, 0x09, 0x64, 0x92, 0x22, 0x79, 0xa8, 0x73, 0x98, 0xaa, 0x4a, 0xe0,
+		0x81, 0xe4, 0x80, 0xbc, 0x1d, 0x26, 0xb9, 0x57, 0xd7, 0x17, 0x61, 0x42,
+		0x9e, 0xd1, 0x36, 0xc3, 0x8c, 0x5d, 0x7b, 0xdb, 0x22, 0xe7, 0x90, 0x73,
+		0xf4, 0x4c, 0xe0, 0x65, 0xcc, 0x31, 0x7b, 0xf2, 0xa2, 0x0b, 0x84, 0xc2,
+		0x74, 0x6a, 0x03, 0x38, 0xe1, 0x19, 0x78, 0x51, 0x88, 0x51, 0x4a, 0xe1,
+		0xe1, 0xda, 0x0b, 0xfe, 0xee, 0x61, 0x0f, 0x41, 0x55, 0xf9, 0xbf, 0x81,
+		0xed, 0x33, 0xee, 0xdf, 0xf9, 0xab, 0x45, 0x5d, 0x7b, 0xd1, 0x9d, 0xb4,
+		0xcf, 0xd0, 0xd0, 0x11, 0x06, 0x38, 0x0a, 0x83, 0x74, 0x2a, 0xd3, 0x56,
+		0xd5, 0x0b, 0xe1, 0x29, 0xb2, 0x01, 0x0b, 0xe0, 0x98, 0x64, 0xa2, 0x21,
+		0x51, 0xb0, 0xd3, 0x12, 0x83, 0x2d, 0x27, 0x45, 0xae, 0x1a, 0x71, 0x7a,
+		0xc6, 0x19, 0xbe, 0x87, 0xcc, 0x8b, 0xe2, 0xf9, 0xe6, 0x47, 0x18, 0x08,
+		0xbf, 0x89, 0xe4, 0xf8, 0x3e, 0x03, 0x1b, 0x5b, 0xd2, 0xa2, 0x04, 0xca,
+		0x09, 0x30, 0x93, 0x48, 0x06, 0xd0, 0x28, 0xe4, 0x69, 0x14, 0x0b, 0x98,
+		0x68, 0xb5, 0x08, 0x03, 0x71, 0x0e, 0x79, 0x12, 0x89, 0x96, 0x75, 0xa7,
+		0xe2, 0x2c, 0x7e, 0xe8, 0x30, 0x7e, 0xc3, 0x31, 0x87, 0x6e, 0xb4, 0x32,
+		0xd4, 0x75, 0x55, 0x91, 0x07, 0x09, 0x06, 0xb3, 0x22, 0xaf, 0x6b, 0xf4,
+		0x41, 0x41, 0xd7, 0x1f, 0x1f, 0xab, 0x0a, 0xf2, 0xc4, 0x91, 0xd3, 0x8e,
+		0x40, 0xf6, 0xa0, 0x90, 0xf7, 0x1b, 0xa3, 0x8f, 0xc0, 0x3b, 0x95, 0x64,
+		0xfe, 0x1b, 0xe0, 0x69, 0x91, 0xc8, 0x6a, 0x9d, 0x23, 0x64, 0x4c, 0x34,
+		0xb0, 0xfe, 0xb5, 0x89, 0x4d, 0x21, 0x39, 0x77, 0x7d, 0xfd, 0x0c, 0x90,
+		0xaf, 0xe6, 0xb1, 0xf9, 0xa1, 0xec, 0xe1, 0x31, 0xf6, 0x58, 0x98, 0xcf,
+		0xdc, 0x5e, 0x53, 0x52, 0x50, 0xc2, 0x0f, 0xdd, 0x9b, 0xd6, 0x76, 0xe6,
+		0xda, 0xf2, 0xb5, 0x24, 0x14, 0xd8, 0x00, 0x95, 0xb1, 0xce, 0xb9, 0x44,
+		0xf3, 0x27, 0x27, 0xaf, 0x31, 0xd9, 0xc1, 0xc0, 0xac, 0x41, 0xe6, 0xf0,
+		0x0c, 0xf4, 0xd4, 0x38, 0x6d, 0x91, 0xef, 0x80, 0x13, 0xa0, 0xdd, 0x22,
+		0x14, 0xe7, 0x8f, 0x80, 0x46, 0xe4, 0x12, 0x8d, 0x72, 0x2c, 0x52, 0xcf,
+		0xae, 0x91, 0xaf, 0xa3, 0x6e, 0xc5, 0x27, 0x33, 0xaf, 0x37, 0x22, 0x75,
+		0x7d, 0x89, 0x4c, 0xf6, 0xaa, 0x52, 0xa1, 0xaa, 0xf4, 0xd9, 0x6a, 0x31,
+		0x25, 0x3d, 0x40, 0xbe, 0x32, 0x9c, 0xb9, 0xb0, 0x80, 0x0c, 0xf7, 0xe6,
+		0xa6, 0x0c, 0xe2, 0x02, 0x2b, 0x71, 0x6e, 0x19, 0xbb, 0xcf, 0x09, 0xf7,
+		0x22, 0x91, 0x57, 0x1a, 0xa3, 0xd3, 0xcf, 0x80, 0x0f, 0x59, 0x81, 0x93,
+		0x26, 0x5b, 0x58, 0x52, 0x50, 0x4f, 0xa1, 0xed, 0xb2, 0x0b, 0x69, 0x71,
+		0x30, 0x4f, 0x81, 0xd2, 0x52, 0x09, 0x94, 0x56, 0x94, 0xd4, 0xad, 0x80,
+		0x0c, 0x33, 0x47, 0x56, 0x9d, 0x6b, 0x52, 0x42, 0x26, 0xf6, 0xc6, 0x29,
+		0x7d, 0xa2, 0xd2, 0x04, 0xbc, 0x2d, 0xd4, 0xf5, 0x6a, 0xbd, 0xfc, 0xb9,
+		0xba, 0x5d, 0x8a, 0x1a, 0x9b, 0xb4, 0xa0, 0x7c, 0xb5, 0x40, 0x4a, 0x71,
+		0x6a, 0x5b, 0xf4, 0xc4, 0xb4, 0xa4, 0xb4, 0xa0, 0x46, 0x4b, 0xe6, 0xdc,
+		0x4a, 0x69, 0xa0, 0xf4, 0x76, 0x30, 0xa9, 0x78, 0x57, 0xfb, 0xa5, 0x07,
+		0xd5, 0x7c, 0x28, 0xb7, 0x54, 0xf0, 0xa3, 0x66, 0xfc, 0xc0, 0xa1, 0x96,
+		0x96, 0xcb, 0xd1, 0x91, 0xe2, 0x91, 0xa7, 0xdd, 0x1e, 0x6d, 0xcd, 0xee,
+		0x43, 0x05, 0xbd, 0x86, 0x42, 0x7e, 0x5f, 0x24, 0x87, 0xf6, 0x15, 0x3a,
+		0xc4, 0x64, 0xb2, 0x2d, 0xc5, 0x4c, 0xd5, 0x20, 0x6b, 0x16, 0x82, 0x03,
+		0x84, 0xa4, 0x8d, 0x64, 0xab, 0x7a, 0xd7, 0x81, 0xe7, 0x78, 0x39, 0xeb,
+		0xd4, 0xbe, 0x82, 0x27, 0x97, 0xf3, 0xd0, 0xa2, 0xb7, 0xf2, 0x71, 0xa6,
+		0x26, 0xae, 0xb7, 0x4b, 0x8e, 0x82, 0xa4, 0x72, 0x6c, 0x1c, 0x7b, 0x52,
+		0xcb, 0xf3, 0x8d, 0x16, 0x02, 0xf9, 0xdf, 0x12, 0x74, 0xd2, 0xeb, 0x6b,
+		0xb2, 0x33, 0x4b, 0x89, 0x61, 0x46, 0xd1, 0xa2, 0xdd, 0x01, 0xe3, 0xae,
+		0x22, 0x9d, 0x43, 0xef, 0xb2, 0x5b, 0x79, 0xda, 0xa1, 0xbb, 0xc9, 0x6e,
+		0xc3, 0x5d, 0xcc, 0xc6, 0x9c, 0xc3, 0xae, 0xe4, 0xec, 0x6d, 0x66, 0xcf,
+		0xe3, 0x78, 0x79, 0xb3, 0x8e, 0x37, 0x1d, 0x72, 0x6a, 0x22, 0xcf, 0x4d,
+		0x0a, 0x2b, 0xb8, 0xff, 0x60, 0x2c, 0xe5, 0x90, 0xb8, 0x08, 0xb8, 0xd8,
+		0x53, 0x2c, 0x7b, 0x70, 0xf9, 0xa4, 0x98, 0xf6, 0xcc, 0xe5, 0x51, 0x6a,
+		0x72, 0x39, 0x84, 0xdc, 0xcb, 0x22, 0x67, 0xf0, 0x4e, 0x42, 0x0f, 0x11,
+		0x9f, 0x24, 0xb1, 0x6f, 0xa0, 0xcd, 0xb9, 0xff, 0xad, 0xa0, 0x3b, 0xcc,
+		0x91, 0x77, 0x35, 0x99, 0x7c, 0x19, 0x4f, 0xa6, 0xe3, 0xc9, 0x15, 0x9a,
+		0x7e, 0x9e, 0x4d, 0x3e, 0xcd, 0x26, 0x9f, 0xbd, 0x53, 0xd4, 0xf3, 0x2d,
+		0x7c, 0xe7, 0xf6, 0xdc, 0xf5, 0xd6, 0xa7, 0x8b, 0xb7, 0xbe, 0x1e, 0x91,
+		0xfa, 0x3f, 0x6d, 0x8f, 0xe7, 0x68, 0xd6, 0x54, 0x36, 0x0b, 0xc9, 0xa9,
+		0x40, 0xb3, 0x84, 0xed, 0x30, 0x7b, 0x5b, 0xf8, 0x1d, 0x84, 0x55, 0x71,
+		0xaa, 0x37, 0xed, 0x29, 0x2d, 0x5c, 0x39, 0xea, 0x83, 0x17, 0xdd, 0x16,
+		0x0d, 0x59, 0x11, 0x85, 0x6d, 0x41, 0x13, 0xc9, 0x99, 0xb2, 0xb9, 0x6a,
+		0x49, 0x3e, 0xe4, 0xbd, 0x31, 0xd8, 0xcf, 0x7f, 0xc0, 0x94, 0xc4, 0xc6,
+		0x24, 0x0a, 0x00, 0x00,
+	},
+		"template/task_view.html",
+	)
+}
+
 func template_top_html() ([]byte, error) {
 	return bindata_read([]byte{
 		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x00, 0xff, 0xaa, 0xae,
@@ -29681,7 +30221,9 @@ var _bindata = map[string]func() ([]byte, error){
 	"template/dashboard.html": template_dashboard_html,
 	"template/layout.html": template_layout_html,
 	"template/queue_create.html": template_queue_create_html,
+	"template/queue_edit.html": template_queue_edit_html,
 	"template/queue_view.html": template_queue_view_html,
+	"template/task_view.html": template_task_view_html,
 	"template/top.html": template_top_html,
 }
 // AssetDir returns the file names below a certain
@@ -29746,8 +30288,12 @@ var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 	}},
 	"template/queue_create.html": &_bintree_t{template_queue_create_html, map[string]*_bintree_t{
 	}},
+	"template/queue_edit.html": &_bintree_t{template_queue_edit_html, map[string]*_bintree_t{
+	}},
 	"template/queue_view.html": &_bintree_t{template_queue_view_html, map[string]*_bintree_t{
 	}},
+	"template/task_view.html": &_bintree_t{template_task_view_html, map[string]*_bintree_t{
+	}},
 	"template/top.html": &_bintree_t{template_top_html, map[string]*_bintree_t{
 	}},
 }}
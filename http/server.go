@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"html/template"
 	stdhttp "net/http"
+	"strings"
 	"time"
 
 	"github.com/borgenk/qdo/third_party/github.com/elazarl/go-bindata-assetfs"
 	"github.com/borgenk/qdo/third_party/github.com/gorilla/mux"
+	"github.com/borgenk/qdo/worker"
 )
 
 var router = mux.NewRouter()
@@ -22,8 +24,22 @@ func RouterParam(r *stdhttp.Request, name string) string {
 
 var Templates = make(map[string]*template.Template)
 
+// ShortID returns the abbreviated form of a task ID.
+func ShortID(ID string) string {
+	if len(ID) < 7 {
+		return ID
+	}
+	return ID[:7]
+}
+
 var FuncMap = template.FuncMap{
-	"ShortID": func(ID string) string { return ID[:7] },
+	"ShortID": ShortID,
+	"UnixTime": func(t int64) string {
+		return time.Unix(t, 0).Format("2006-01-02 15:04:05")
+	},
+	"Rate":      formatRate,
+	"Join":      strings.Join,
+	"Executors": worker.ExecutorNames,
 	"eq": func(a, b interface{}) bool {
 		return a == b
 	},
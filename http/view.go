@@ -15,6 +15,8 @@ var templateList = []string{
 	"dashboard.html",
 	"queue_view.html",
 	"queue_create.html",
+	"queue_edit.html",
+	"task_view.html",
 }
 
 func init() {
@@ -23,7 +25,13 @@ func init() {
 	r.HandleFunc("/queue", viewDashboard).Methods("GET")
 	r.HandleFunc("/queue/new", viewQueueCreate).Methods("GET", "POST")
 	r.HandleFunc("/queue/{queue_id}", viewQueue).Methods("GET")
+	r.HandleFunc("/queue/{queue_id}/edit", viewQueueEdit).Methods("GET", "POST")
+	r.HandleFunc("/queue/{queue_id}/pause", viewQueuePause).Methods("POST")
+	r.HandleFunc("/queue/{queue_id}/resume", viewQueueResume).Methods("POST")
+	r.HandleFunc("/queue/{queue_id}/scheduled/run", viewRunScheduledTasks).Methods("POST")
 	r.HandleFunc("/queue/{queue_id}/{type}", viewQueue).Methods("GET")
+	r.HandleFunc("/queue/{queue_id}/task/{task_id}/run", viewRunTask).Methods("POST")
+	r.HandleFunc("/queue/{queue_id}/task/{task_id}", viewTask).Methods("GET")
 
 	for _, v := range templateList {
 		a, err := Asset("template/" + v)
@@ -66,18 +74,32 @@ type QueueRow struct {
 	TasksScheduled  int64
 }
 
+type DashboardView struct {
+	Queues []QueueRow
+	Hosts  []worker.HostLimitState
+}
+
 func viewDashboard(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	queues, err := core.GetAllQueues()
 	if err != nil {
 		stdhttp.Error(w, "", stdhttp.StatusInternalServerError)
 		return
 	}
+	hosts, err := core.GetHostLimits()
+	if err != nil {
+		stdhttp.Error(w, "", stdhttp.StatusInternalServerError)
+		return
+	}
 
 	res := []QueueRow{}
 	for _, v := range queues {
+		status := "Active"
+		if v.IsPaused() {
+			status = "Paused"
+		}
 		q := QueueRow{
 			ID:              v.ID,
-			Status:          "Active",
+			Status:          status,
 			TasksProcessing: v.GetStats().InProcessing.Get(),
 			TasksWaiting:    v.GetStats().InQueue.Get(),
 			TasksScheduled:  v.GetStats().InScheduled.Get(),
@@ -88,8 +110,11 @@ func viewDashboard(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 		Header: Header{
 			Title: "Dashboard | QDo",
 		},
-		Title:  "Dashboard",
-		Result: res,
+		Title: "Dashboard",
+		Result: &DashboardView{
+			Queues: res,
+			Hosts:  hosts,
+		},
 	}
 	renderTemplate(w, "dashboard.html", p)
 }
@@ -178,6 +203,88 @@ func viewQueue(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	renderTemplate(w, "queue_view.html", p)
 }
 
+func viewQueueEdit(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	queueID := mux.Vars(r)["queue_id"]
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	if r.Method == "POST" {
+		config := q.ConfigCopy()
+		err = parseQueueConfig(r, config, false)
+		if err == nil {
+			err = core.UpdateQueue(queueID, config)
+		}
+		if err != nil {
+			stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+			return
+		}
+		stdhttp.Redirect(w, r, "/queue/"+queueID, 303)
+		return
+	}
+	h := Header{
+		Title: fmt.Sprintf("Edit %s | QDo", q.ID),
+	}
+	p := &Page{
+		Header: h,
+		Title:  "Edit queue " + q.ID,
+		Result: q,
+	}
+	renderTemplate(w, "queue_edit.html", p)
+}
+
+func viewQueuePause(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	queueID := mux.Vars(r)["queue_id"]
+	err := core.PauseQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	stdhttp.Redirect(w, r, "/queue/"+queueID, 303)
+}
+
+func viewQueueResume(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	queueID := mux.Vars(r)["queue_id"]
+	err := core.ResumeQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	stdhttp.Redirect(w, r, "/queue/"+queueID, 303)
+}
+
+func viewRunTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	err = q.RunTask(vars["task_id"])
+	if err != nil {
+		pendingTaskError(w, err)
+		return
+	}
+	stdhttp.Redirect(w, r, "/queue/"+queueID+"/scheduled", 303)
+}
+
+func viewRunScheduledTasks(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	queueID := mux.Vars(r)["queue_id"]
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	_, err = q.RunScheduledTasks()
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	stdhttp.Redirect(w, r, "/queue/"+queueID+"/scheduled", 303)
+}
+
 func viewQueueCreate(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	if r.Method == "POST" {
 		createQueue(w, r)
@@ -193,3 +300,42 @@ func viewQueueCreate(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	}
 	renderTemplate(w, "queue_create.html", p)
 }
+
+type TaskView struct {
+	Q        *worker.QueueManager
+	Detail   *worker.TaskDetail
+	Pipeline *worker.Pipeline
+}
+
+func viewTask(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	vars := mux.Vars(r)
+	queueID := vars["queue_id"]
+	q, err := core.GetQueue(queueID)
+	if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	}
+	detail, err := q.GetTaskDetail(vars["task_id"])
+	if err == worker.ErrTaskNotFound {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusNotFound)
+		return
+	} else if err != nil {
+		stdhttp.Error(w, err.Error(), stdhttp.StatusInternalServerError)
+		return
+	}
+	h := Header{
+		Title: fmt.Sprintf("%s | %s | QDo", ShortID(detail.ID), q.ID),
+	}
+	p := &Page{
+		Header: h,
+		Title:  detail.ID,
+		Result: &TaskView{
+			Q:      q,
+			Detail: detail,
+		},
+	}
+	if detail.Task != nil && detail.Task.PipelineID != "" {
+		p.Result.(*TaskView).Pipeline, _ = core.GetPipeline(detail.Task.PipelineID)
+	}
+	renderTemplate(w, "task_view.html", p)
+}
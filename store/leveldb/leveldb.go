@@ -62,6 +62,18 @@ func (s *Store) Delete(key []byte) error {
 	return s.db.Delete(key, nil)
 }
 
+func (s *Store) NewBatch() store.Batch {
+	return &Batch{}
+}
+
+func (s *Store) Write(b store.Batch) error {
+	err := s.db.Write(&b.(*Batch).batch, nil)
+	if err != nil {
+		return store.ErrWrite
+	}
+	return nil
+}
+
 func (s *Store) NewIterator(r *store.Range) store.Iterator {
 	var rr = &util.Range{}
 	if r != nil {
@@ -71,6 +83,18 @@ func (s *Store) NewIterator(r *store.Range) store.Iterator {
 	return &Iterator{s.db.NewIterator(rr, nil)}
 }
 
+type Batch struct {
+	batch leveldb.Batch
+}
+
+func (b *Batch) Put(key, val []byte) {
+	b.batch.Put(key, val)
+}
+
+func (b *Batch) Delete(key []byte) {
+	b.batch.Delete(key)
+}
+
 type Iterator struct {
 	iter iterator.Iterator
 }
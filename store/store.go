@@ -18,6 +18,15 @@ type Store interface {
 	Delete(key []byte) error
 	Close() error
 	NewIterator(r *Range) Iterator
+	NewBatch() Batch
+	Write(b Batch) error
+}
+
+// Batch collects puts and deletes that Write applies to the store at once,
+// either all of them or none.
+type Batch interface {
+	Put(key, val []byte)
+	Delete(key []byte)
 }
 
 type Iterator interface {
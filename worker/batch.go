@@ -0,0 +1,659 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+const (
+	BatchStateOpen   = "open"   // Tasks may be added.
+	BatchStateClosed = "closed" // No more tasks, waiting for members to finish.
+	BatchStateDone   = "done"   // All members finished.
+)
+
+const (
+	CallbackStatePending = "pending" // Waiting to be posted.
+	CallbackStateSent    = "sent"    // Posted and accepted by the callback URL.
+	CallbackStateFailed  = "failed"  // Refused by the callback URL or out of tries.
+)
+
+// State of a batch member as stored in its member record. A member is given
+// its final state before its task leaves the queue lines.
+const (
+	memberPending   byte = 'p'
+	memberSucceeded byte = 's'
+	memberFailed    byte = 'f'
+)
+
+// callbackIdle is how long the callback sender sleeps with nothing due.
+const callbackIdle = time.Minute
+
+var (
+	ErrBatchNotFound        = errors.New("Batch error: batch not found")
+	ErrBatchAlreadyExist    = errors.New("Batch error: batch already exist")
+	ErrBatchInvalidID       = errors.New("Batch error: invalid id")
+	ErrBatchInvalidCallback = errors.New("Batch error: invalid callback URL")
+	ErrBatchNotOpen         = errors.New("Batch error: batch is closed")
+	ErrBatchDuplicateTask   = errors.New("Batch error: task already in batch")
+	ErrBatchCorrupt         = errors.New("Batch error: stored batch is corrupt")
+)
+
+// Batch groups tasks of a queue so their completion can be followed as one.
+// Once the batch is closed and every member finished, the callback URL is
+// posted the batch.
+type Batch struct {
+	ID            string `json:"id"`
+	State         string `json:"state"`    // One of open, closed or done.
+	Callback      string `json:"callback"` // URL posted once done, empty for none.
+	CallbackState string `json:"callback_state"`
+	CallbackTries int32  `json:"callback_tries"`
+	CallbackAt    int64  `json:"callback_at"` // Time of the next try while pending, else of the last.
+	Total         int64  `json:"total"`
+	Pending       int64  `json:"pending"`
+	Succeeded     int64  `json:"succeeded"`
+	Failed        int64  `json:"failed"` // Members moved to the dead queue, expired, dropped or cancelled.
+	CreatedAt     int64  `json:"created_at"`
+	UpdatedAt     int64  `json:"updated_at"`
+	CompletedAt   int64  `json:"completed_at"`
+}
+
+// Validate checks the id and callback of a new batch.
+func (b *Batch) Validate() error {
+	if !validTaskID.MatchString(b.ID) {
+		return ErrBatchInvalidID
+	}
+	if b.Callback != "" {
+		u, err := url.Parse(b.Callback)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrBatchInvalidCallback
+		}
+	}
+	return nil
+}
+
+// count adds n to the counter of the given member state.
+func (b *Batch) count(state byte, n int64) {
+	switch state {
+	case memberPending:
+		b.Pending += n
+	case memberSucceeded:
+		b.Succeeded += n
+	case memberFailed:
+		b.Failed += n
+	}
+	b.Total = b.Pending + b.Succeeded + b.Failed
+}
+
+// BTCH(4)|pending(8)|succeeded(8)|failed(8)|createdAt(8)|updatedAt(8)|
+// completedAt(8)|sizeOfID(4)|id(x)|sizeOfState(4)|state(x)|
+// sizeOfCallback(4)|callback(x)|sizeOfCallbackTaskID(4)|callbackTaskID(x)|
+// callbackTries(4)|callbackAt(8)|sizeOfCallbackState(4)|callbackState(x)
+//
+// The callback task id is left empty, it was set when callbacks were sent as
+// tasks of the queue. Records from then end after it.
+func (b *Batch) Serialize() []byte {
+	out := []byte("BTCH")
+	out = appendUint64(out, uint64(b.Pending))
+	out = appendUint64(out, uint64(b.Succeeded))
+	out = appendUint64(out, uint64(b.Failed))
+	out = appendUint64(out, uint64(b.CreatedAt))
+	out = appendUint64(out, uint64(b.UpdatedAt))
+	out = appendUint64(out, uint64(b.CompletedAt))
+	out = appendString(out, b.ID)
+	out = appendString(out, b.State)
+	out = appendString(out, b.Callback)
+	out = appendString(out, "")
+	out = appendUint32(out, uint32(b.CallbackTries))
+	out = appendUint64(out, uint64(b.CallbackAt))
+	out = appendString(out, b.CallbackState)
+	return out
+}
+
+func UnserializeBatch(value []byte) (*Batch, error) {
+	if !bytes.HasPrefix(value, []byte("BTCH")) {
+		return nil, ErrBatchCorrupt
+	}
+	b := &Batch{}
+	pending, n := readUint64(value, 4)
+	succeeded, n := readUint64(value, n)
+	failed, n := readUint64(value, n)
+	createdAt, n := readUint64(value, n)
+	updatedAt, n := readUint64(value, n)
+	completedAt, n := readUint64(value, n)
+	b.ID, n = readString(value, n)
+	b.State, n = readString(value, n)
+	b.Callback, n = readString(value, n)
+	_, n = readString(value, n)
+	if n >= 0 && n < len(value) {
+		var tries uint32
+		var at uint64
+		tries, n = readUint32(value, n)
+		at, n = readUint64(value, n)
+		b.CallbackState, n = readString(value, n)
+		b.CallbackTries, b.CallbackAt = int32(tries), int64(at)
+	}
+	if n < 0 {
+		return nil, ErrBatchCorrupt
+	}
+	b.Pending = int64(pending)
+	b.Succeeded = int64(succeeded)
+	b.Failed = int64(failed)
+	b.CreatedAt = int64(createdAt)
+	b.UpdatedAt = int64(updatedAt)
+	b.CompletedAt = int64(completedAt)
+	b.Total = b.Pending + b.Succeeded + b.Failed
+	return b, nil
+}
+
+func NewBatchTable(ID string, db store.Store, prefix, suffix []byte) *batchTable {
+	return &batchTable{
+		ID:     ID,
+		db:     db,
+		prefix: prefix,
+		suffix: suffix,
+	}
+}
+
+// batchTable stores the batches of a queue. Every member has a record with
+// its state following the record of its batch, the counters of the batch are
+// kept in step with them.
+// Key format: [line id] \x00 [key type] \x00 [batch id]
+// Member key format: [line id] \x00 [key type] \x00 [batch id] \x00 [task id]
+type batchTable struct {
+	ID     string
+	db     store.Store
+	prefix []byte
+	suffix []byte
+}
+
+func (b *batchTable) key(batchID string) []byte {
+	k := make([]byte, 0, len(b.prefix)+len(batchID))
+	k = append(k, b.prefix...)
+	return append(k, []byte(batchID)...)
+}
+
+func (b *batchTable) memberKey(batchID, taskID string) []byte {
+	k := b.key(batchID)
+	k = append(k, 0)
+	return append(k, []byte(taskID)...)
+}
+
+func (b *batchTable) Get(batchID string) (*Batch, error) {
+	value, ok := b.get(b.key(batchID))
+	if !ok {
+		return nil, ErrBatchNotFound
+	}
+	batch, err := UnserializeBatch(value)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/batch/%s - reading failed", b.ID, batchID), err)
+		return nil, err
+	}
+	return batch, nil
+}
+
+// GetAll returns all batches, skipping over their member records and corrupt
+// batches.
+func (b *batchTable) GetAll() ([]Batch, error) {
+	res := []Batch{}
+	iter := b.db.NewIterator(nil)
+	defer iter.Close()
+	iter.Seek(b.prefix)
+	for iter.Valid() {
+		if bytes.Compare(iter.Key(), b.suffix) > 0 {
+			break
+		}
+		batchID := string(iter.Key()[len(b.prefix):])
+		if i := strings.IndexByte(batchID, 0); i >= 0 {
+			// Member of a batch without a record.
+			batchID = batchID[:i]
+		}
+		batch, err := UnserializeBatch(append([]byte{}, iter.Value()...))
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/batch/%s - skipping batch", b.ID, batchID), err)
+		} else {
+			res = append(res, *batch)
+		}
+		iter.Seek(append(b.key(batchID), 1))
+	}
+	return res, nil
+}
+
+func (b *batchTable) Put(batch *Batch) error {
+	batch.UpdatedAt = time.Now().Unix()
+	err := b.db.Put(b.key(batch.ID), batch.Serialize())
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/batch/%s - storing failed", b.ID, batch.ID), err)
+		return err
+	}
+	return nil
+}
+
+// getMember returns the state of a member, false if the task is not one.
+func (b *batchTable) getMember(batchID, taskID string) (byte, bool) {
+	value, ok := b.get(b.memberKey(batchID, taskID))
+	if !ok || len(value) == 0 {
+		return 0, false
+	}
+	return value[0], true
+}
+
+func (b *batchTable) putMember(batchID, taskID string, state byte) error {
+	return b.db.Put(b.memberKey(batchID, taskID), []byte{state})
+}
+
+// eachMember calls fn with the task id and state of every member of the
+// batch.
+func (b *batchTable) eachMember(batchID string, fn func(taskID string, state byte)) {
+	prefix := append(b.key(batchID), 0)
+	iter := b.db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(prefix); iter.Valid(); iter.Next() {
+		k := iter.Key()
+		if !bytes.HasPrefix(k, prefix) {
+			break
+		}
+		v := iter.Value()
+		if len(v) == 0 {
+			continue
+		}
+		fn(string(k[len(prefix):]), v[0])
+	}
+}
+
+// Delete removes the batch together with its member records.
+func (b *batchTable) Delete(batchID string) error {
+	keys := [][]byte{b.key(batchID)}
+	prefix := append(b.key(batchID), 0)
+	iter := b.db.NewIterator(nil)
+	for iter.Seek(prefix); iter.Valid(); iter.Next() {
+		if !bytes.HasPrefix(iter.Key(), prefix) {
+			break
+		}
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, k := range keys {
+		err := b.db.Delete(k)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *batchTable) get(k []byte) ([]byte, bool) {
+	iter := b.db.NewIterator(nil)
+	defer iter.Close()
+	iter.Seek(k)
+	if !iter.Valid() || !bytes.Equal(iter.Key(), k) {
+		return nil, false
+	}
+	return append([]byte{}, iter.Value()...), true
+}
+
+// CreateBatch stores a new open batch.
+func (q *QueueManager) CreateBatch(batch *Batch) error {
+	err := batch.Validate()
+	if err != nil {
+		return err
+	}
+	if batch.Callback != "" {
+		err = q.checkTarget(&Task{Target: batch.Callback})
+		if err != nil {
+			return err
+		}
+	}
+	q.batchMu.Lock()
+	defer q.batchMu.Unlock()
+
+	if _, err := q.batches.Get(batch.ID); err == nil {
+		return ErrBatchAlreadyExist
+	}
+	batch.State = BatchStateOpen
+	batch.CreatedAt = time.Now().Unix()
+	batch.CompletedAt = 0
+	batch.CallbackState, batch.CallbackTries, batch.CallbackAt = "", 0, 0
+	batch.count(memberPending, 0)
+	log.Infof("queue/%s/batch/%s - created", q.ID, batch.ID)
+	return q.batches.Put(batch)
+}
+
+func (q *QueueManager) GetBatch(batchID string) (*Batch, error) {
+	return q.batches.Get(batchID)
+}
+
+func (q *QueueManager) GetBatches() ([]Batch, error) {
+	return q.batches.GetAll()
+}
+
+// DeleteBatch forgets a batch. Its members stay in the queue.
+func (q *QueueManager) DeleteBatch(batchID string) error {
+	q.batchMu.Lock()
+	defer q.batchMu.Unlock()
+
+	if _, err := q.batches.Get(batchID); err != nil {
+		return err
+	}
+	log.Infof("queue/%s/batch/%s - deleting", q.ID, batchID)
+	return q.batches.Delete(batchID)
+}
+
+// EnqueueBatch adds the task as a member of an open batch.
+func (q *QueueManager) EnqueueBatch(task *Task, scheduled int64, batchID string) (*Task, error) {
+	q.batchMu.Lock()
+	defer q.batchMu.Unlock()
+
+	batch, err := q.batches.Get(batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.State != BatchStateOpen {
+		return nil, ErrBatchNotOpen
+	}
+	if task.ID == "" {
+		task.ID = <-q.newTaskID
+	} else if _, ok := q.batches.getMember(batchID, task.ID); ok {
+		return nil, ErrBatchDuplicateTask
+	}
+	task.BatchID = batchID
+
+	// The member is recorded first, a member without task after a crash is
+	// dropped when the queue starts again.
+	err = q.batches.putMember(batchID, task.ID, memberPending)
+	if err != nil {
+		return nil, err
+	}
+	res, err := q.Enqueue(task, scheduled)
+	if err != nil {
+		q.batches.db.Delete(q.batches.memberKey(batchID, task.ID))
+		return nil, err
+	}
+	batch.count(memberPending, 1)
+	err = q.batches.Put(batch)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CloseBatch stops a batch from taking more tasks. It is done as soon as all
+// its members are.
+func (q *QueueManager) CloseBatch(batchID string) (*Batch, error) {
+	q.batchMu.Lock()
+	defer q.batchMu.Unlock()
+
+	batch, err := q.batches.Get(batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.State != BatchStateOpen {
+		return batch, nil
+	}
+	log.Infof("queue/%s/batch/%s - closed with %d task(s)", q.ID, batch.ID, batch.Total)
+	batch.State = BatchStateClosed
+	err = q.completeBatch(batch)
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// batchTaskDone records the final state of a batch member, succeeded or
+// failed. Tasks delivered twice are counted once.
+func (q *QueueManager) batchTaskDone(task *Task, succeeded bool) {
+	state := memberFailed
+	if succeeded {
+		state = memberSucceeded
+	}
+	q.setMemberState(task, state)
+}
+
+// batchTaskRequeued records a failed batch member being retried. A batch
+// already done is closed again and calls back once more when done.
+func (q *QueueManager) batchTaskRequeued(task *Task) {
+	q.setMemberState(task, memberPending)
+}
+
+func (q *QueueManager) setMemberState(task *Task, state byte) {
+	q.batchMu.Lock()
+	defer q.batchMu.Unlock()
+
+	batch, err := q.batches.Get(task.BatchID)
+	if err != nil {
+		// The batch was deleted.
+		return
+	}
+	old, ok := q.batches.getMember(batch.ID, task.ID)
+	if !ok || old == state {
+		return
+	}
+	err = q.batches.putMember(batch.ID, task.ID, state)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/batch/%s - storing member %s failed", q.ID, batch.ID, task.ID), err)
+		return
+	}
+	batch.count(old, -1)
+	batch.count(state, 1)
+	if state == memberPending && batch.State == BatchStateDone {
+		// A callback not posted yet is posted once done again.
+		batch.State = BatchStateClosed
+		batch.CompletedAt = 0
+		batch.CallbackState, batch.CallbackTries, batch.CallbackAt = "", 0, 0
+	}
+	q.completeBatch(batch)
+}
+
+// completeBatch stores the batch, marking it done and its callback due once
+// it is closed and has no pending members. The caller holds batchMu.
+func (q *QueueManager) completeBatch(batch *Batch) error {
+	if batch.State != BatchStateClosed || batch.Pending > 0 {
+		return q.batches.Put(batch)
+	}
+	log.Infof("queue/%s/batch/%s - done, %d succeeded and %d failed", q.ID, batch.ID, batch.Succeeded, batch.Failed)
+	batch.State = BatchStateDone
+	batch.CompletedAt = time.Now().Unix()
+	batch.CallbackState, batch.CallbackTries, batch.CallbackAt = "", 0, 0
+	if batch.Callback != "" {
+		batch.CallbackState = CallbackStatePending
+		batch.CallbackAt = batch.CompletedAt
+	}
+	err := q.batches.Put(batch)
+	if err != nil {
+		return err
+	}
+	if batch.Callback != "" {
+		q.triggerCallbacks()
+	}
+	return nil
+}
+
+// triggerCallbacks wakes up the callback sender after a batch is done.
+func (q *QueueManager) triggerCallbacks() {
+	select {
+	case q.callbackSignal <- struct{}{}:
+	default:
+	}
+}
+
+// callbacks posts the callbacks of done batches. They are sent apart from
+// the tasks of the queue, so also in pull mode and whatever the depth limits
+// of the queue, and are retried by the queue's retry policy until accepted,
+// refused or out of tries. Callbacks due when the queue starts are sent
+// right away.
+func (q *QueueManager) callbacks() {
+	var wait time.Duration
+	for {
+		select {
+		case <-q.quit:
+			return
+		case <-q.callbackSignal:
+		case <-time.After(wait):
+		}
+		wait = q.sendCallbacks()
+	}
+}
+
+// sendCallbacks posts every callback due and returns how long until the
+// next one is.
+func (q *QueueManager) sendCallbacks() time.Duration {
+	batches, err := q.batches.GetAll()
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/batch - reading failed", q.ID), err)
+		return callbackIdle
+	}
+	wait := callbackIdle
+	for i := range batches {
+		batch := &batches[i]
+		if batch.State != BatchStateDone || batch.CallbackState != CallbackStatePending {
+			continue
+		}
+		if at := batch.CallbackAt; at > time.Now().Unix() {
+			if d := time.Duration(at-time.Now().Unix()) * time.Second; d < wait {
+				wait = d
+			}
+			continue
+		}
+		if d := q.sendCallback(batch); d > 0 && d < wait {
+			wait = d
+		}
+		select {
+		case <-q.quit:
+			return callbackIdle
+		default:
+		}
+	}
+	return wait
+}
+
+// sendCallback posts the batch to its callback URL and stores the outcome.
+// It returns how long until the next try, 0 if there is none.
+func (q *QueueManager) sendCallback(batch *Batch) time.Duration {
+	q.qmWaitGroup.Add(1)
+	defer q.qmWaitGroup.Done()
+
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/batch/%s - encoding callback failed", q.ID, batch.ID), err)
+		return 0
+	}
+	c, _ := q.getConfig()
+	// Callbacks are HTTP whatever executor the queue delivers tasks with.
+	callbackConfig := *c
+	callbackConfig.ExecutorOptions = nil
+	e, err := newHTTPExecutor(q.ID, &callbackConfig)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/batch/%s - posting callback failed", q.ID, batch.ID), err)
+		return 0
+	}
+	res := e.Execute(&Task{
+		Target:      batch.Callback,
+		ContentType: "application/json",
+		Payload:     string(payload),
+	})
+	e.Close()
+
+	q.batchMu.Lock()
+	defer q.batchMu.Unlock()
+
+	current, err := q.batches.Get(batch.ID)
+	if err != nil || current.State != BatchStateDone || current.CallbackState != CallbackStatePending ||
+		current.CompletedAt != batch.CompletedAt {
+		// Deleted or reopened while posting.
+		return 0
+	}
+	current.CallbackTries = current.CallbackTries + 1
+	current.CallbackAt = time.Now().Unix()
+	var wait time.Duration
+	switch {
+	case res.Outcome == OutcomeSuccess:
+		log.Infof("queue/%s/batch/%s - callback posted", q.ID, batch.ID)
+		current.CallbackState = CallbackStateSent
+	case res.Outcome == OutcomeFail:
+		log.Error(fmt.Sprintf("queue/%s/batch/%s - callback refused", q.ID, batch.ID), res.Err)
+		current.CallbackState = CallbackStateFailed
+	case c.TaskMaxTries > 0 && current.CallbackTries >= c.TaskMaxTries:
+		log.Infof("queue/%s/batch/%s - callback max tries reached (%d)", q.ID, batch.ID, current.CallbackTries)
+		current.CallbackState = CallbackStateFailed
+	default:
+		delay := c.Retry.Delay(current.CallbackTries)
+		current.CallbackAt += int64(delay)
+		wait = time.Duration(delay) * time.Second
+	}
+	err = q.batches.Put(current)
+	if err != nil {
+		return 0
+	}
+	return wait
+}
+
+// recoverBatches brings the counters of unfinished batches in step with
+// their members after a restart. Members left pending are checked against
+// the queue lines, as the daemon may have stopped between a member being
+// added and its task.
+func (q *QueueManager) recoverBatches() {
+	q.batchMu.Lock()
+	defer q.batchMu.Unlock()
+
+	batches, err := q.batches.GetAll()
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/batch - reading failed", q.ID), err)
+		return
+	}
+	for i := range batches {
+		batch := &batches[i]
+		if batch.State == BatchStateDone {
+			continue
+		}
+		members := map[string]byte{}
+		q.batches.eachMember(batch.ID, func(taskID string, state byte) {
+			members[taskID] = state
+		})
+		batch.Pending, batch.Succeeded, batch.Failed = 0, 0, 0
+		for taskID, state := range members {
+			if state == memberPending {
+				state = q.memberStateOf(batch.ID, taskID)
+			}
+			if state == 0 {
+				q.batches.db.Delete(q.batches.memberKey(batch.ID, taskID))
+				continue
+			}
+			if state != members[taskID] {
+				q.batches.putMember(batch.ID, taskID, state)
+			}
+			batch.count(state, 1)
+		}
+		log.Infof("queue/%s/batch/%s - recovered, %d of %d task(s) pending", q.ID, batch.ID, batch.Pending, batch.Total)
+		q.completeBatch(batch)
+	}
+}
+
+// memberStateOf finds the state of a pending member from where its task is.
+// As members get their final state before their task leaves the queue
+// lines, a pending member whose task is in none of them never made it to the
+// queue and zero is returned. One in the dead queue was being moved there.
+func (q *QueueManager) memberStateOf(batchID, taskID string) byte {
+	state := memberPending
+	task, err := q.waitQueue.Get(taskID)
+	if err != nil {
+		task, err = q.scheduleQueue.Get(taskID)
+	}
+	if err != nil {
+		dead, deadErr := q.deadQueue.Get(taskID)
+		if deadErr != nil {
+			return 0
+		}
+		task, state = &dead.Task, memberFailed
+	}
+	if task.BatchID != batchID {
+		return 0
+	}
+	return state
+}
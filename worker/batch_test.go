@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnserializeBatch(t *testing.T) {
+	b := &Batch{
+		ID:            "report-42",
+		State:         BatchStateDone,
+		Callback:      "http://127.0.0.1/done",
+		Pending:       0,
+		Succeeded:     8,
+		Failed:        2,
+		Total:         10,
+		CreatedAt:     1400000000,
+		UpdatedAt:     1400000060,
+		CompletedAt:   1400000060,
+		CallbackState: CallbackStatePending,
+		CallbackTries: 1,
+		CallbackAt:    1400000090,
+	}
+	value := b.Serialize()
+	got, err := UnserializeBatch(value)
+	if err != nil || !reflect.DeepEqual(got, b) {
+		t.Errorf("Expected %+v, got %+v, %v", b, got, err)
+	}
+
+	// Records stored before callbacks had a state end after the callback
+	// task id.
+	legacy := value[:len(value)-4-8-4-len(b.CallbackState)]
+	want := *b
+	want.CallbackState, want.CallbackTries, want.CallbackAt = "", 0, 0
+	got, err = UnserializeBatch(legacy)
+	if err != nil || !reflect.DeepEqual(got, &want) {
+		t.Errorf("Expected %+v, got %+v, %v", &want, got, err)
+	}
+
+	values := [][]byte{nil, []byte("JUNK")}
+	for n := 0; n < len(value); n++ {
+		if n != len(legacy) {
+			values = append(values, value[:n])
+		}
+	}
+	for _, value := range values {
+		got, err := UnserializeBatch(value)
+		if err != ErrBatchCorrupt {
+			t.Errorf("Expected ErrBatchCorrupt for %q, got %+v, %v", value, got, err)
+		}
+	}
+}
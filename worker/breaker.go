@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+)
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// DefaultBreakerCooldown is the number of seconds an open breaker waits
+// before probing its host, used when a queue sets none.
+const DefaultBreakerCooldown int32 = 30
+
+// BreakerState is a snapshot of the circuit breaker of a target host.
+type BreakerState struct {
+	Host     string `json:"host"`
+	State    string `json:"state"`    // One of closed, open or half-open.
+	Failures int32  `json:"failures"` // Consecutive failed deliveries.
+	ProbeIn  int64  `json:"probe_in"` // Seconds until an open breaker lets a probe through.
+}
+
+type breaker struct {
+	state    string
+	failures int32
+	openedAt time.Time
+	probing  bool // A probe is in flight.
+}
+
+// breakerTable holds a circuit breaker per target host of a queue. After
+// BreakerThreshold consecutive failures the breaker of a host opens and tasks
+// to it are deferred. Once the cooldown has passed a single task is let
+// through as a probe, closing the breaker again if it succeeds. Hosts that
+// have not failed since their last success are not kept.
+type breakerTable struct {
+	queueID  string
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+func NewBreakerTable(queueID string) *breakerTable {
+	return &breakerTable{
+		queueID:  queueID,
+		breakers: map[string]*breaker{},
+	}
+}
+
+// breakerHost returns the host a target is counted against.
+func breakerHost(target string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+func breakerCooldown(c *Config) time.Duration {
+	cooldown := c.BreakerCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+	return time.Duration(cooldown) * time.Second
+}
+
+// allow reports whether a task to the host may be dispatched now, and if so
+// whether it is the probe of a half-open breaker. Otherwise it returns how
+// long to defer the task.
+func (t *breakerTable) allow(host string, c *Config) (ok bool, probe bool, wait time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, found := t.breakers[host]
+	if !found || c.BreakerThreshold <= 0 {
+		return true, false, 0
+	}
+	cooldown := breakerCooldown(c)
+	switch b.state {
+	case BreakerOpen:
+		wait = b.openedAt.Add(cooldown).Sub(time.Now())
+		if wait > 0 {
+			return false, false, wait
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		log.Infof("queue/%s/breaker/%s - half-open, sending probe", t.queueID, host)
+		return true, true, 0
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, false, cooldown
+		}
+		b.probing = true
+		return true, true, 0
+	}
+	return true, false, 0
+}
+
+// breakerFailure reports whether the outcome of a delivery counts against the
+// breaker of its host. A host asking us to back off with Retry-After is
+// failing as much as one not answering.
+func breakerFailure(outcome Outcome) bool {
+	return outcome == OutcomeRetry || outcome == OutcomeRetryAfter
+}
+
+// record counts the result of a delivery to the host.
+func (t *breakerTable) record(host string, failed bool, c *Config) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, found := t.breakers[host]
+	if !failed {
+		if found {
+			if b.state != BreakerClosed {
+				log.Infof("queue/%s/breaker/%s - closed", t.queueID, host)
+			}
+			delete(t.breakers, host)
+		}
+		return
+	}
+	if c.BreakerThreshold <= 0 {
+		return
+	}
+	if !found {
+		b = &breaker{state: BreakerClosed}
+		t.breakers[host] = b
+	}
+	b.failures++
+	b.probing = false
+	switch {
+	case b.state == BreakerHalfOpen:
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		log.Infof("queue/%s/breaker/%s - probe failed, open again", t.queueID, host)
+	case b.state == BreakerClosed && b.failures >= c.BreakerThreshold:
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		log.Infof("queue/%s/breaker/%s - open after %d consecutive failures", t.queueID, host, b.failures)
+	}
+}
+
+// cancelProbe lets another task probe the host when the probe ended without
+// reaching it.
+func (t *breakerTable) cancelProbe(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if b, found := t.breakers[host]; found {
+		b.probing = false
+	}
+}
+
+// States returns the breakers of all hosts with failures, sorted by host.
+func (t *breakerTable) States(c *Config) []BreakerState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	res := make([]BreakerState, 0, len(t.breakers))
+	for host, b := range t.breakers {
+		s := BreakerState{
+			Host:     host,
+			State:    b.state,
+			Failures: b.failures,
+		}
+		if b.state == BreakerOpen {
+			wait := b.openedAt.Add(breakerCooldown(c)).Sub(now)
+			if wait > 0 {
+				s.ProbeIn = int64((wait + time.Second - 1) / time.Second)
+			}
+		}
+		res = append(res, s)
+	}
+	sort.Sort(byBreakerHost(res))
+	return res
+}
+
+type byBreakerHost []BreakerState
+
+func (s byBreakerHost) Len() int           { return len(s) }
+func (s byBreakerHost) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byBreakerHost) Less(i, j int) bool { return s[i].Host < s[j].Host }
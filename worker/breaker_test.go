@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+)
+
+func TestBreakerFailure(t *testing.T) {
+	tests := []struct {
+		outcome Outcome
+		want    bool
+	}{
+		{OutcomeSuccess, false},
+		{OutcomeRetry, true},
+		{OutcomeRetryAfter, true},
+		{OutcomeFail, false},
+	}
+	for _, test := range tests {
+		if got := breakerFailure(test.outcome); got != test.want {
+			t.Errorf("Expected %v for outcome %d, got %v", test.want, test.outcome, got)
+		}
+	}
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	log.InitLog(log.New())
+	c := &Config{BreakerThreshold: 2, BreakerCooldown: 10}
+	b := NewBreakerTable("foo")
+	const host = "example.com"
+
+	// cooldown moves the open breaker of host to the end of its cooldown.
+	cooldown := func() {
+		b.breakers[host].openedAt = time.Now().Add(-breakerCooldown(c))
+	}
+	state := func() string {
+		s := b.States(c)
+		if len(s) == 0 {
+			return BreakerClosed
+		}
+		return s[0].State
+	}
+	steps := []struct {
+		name  string
+		do    func()
+		state string
+		allow bool
+		probe bool
+	}{
+		{"no failures", func() {}, BreakerClosed, true, false},
+		{"below threshold", func() { b.record(host, true, c) }, BreakerClosed, true, false},
+		{"threshold reached", func() { b.record(host, true, c) }, BreakerOpen, false, false},
+		{"cooldown passed", cooldown, BreakerHalfOpen, true, true},
+		{"probe in flight", func() {}, BreakerHalfOpen, false, false},
+		{"probe failed", func() { b.record(host, true, c) }, BreakerOpen, false, false},
+		{"cooldown passed again", cooldown, BreakerHalfOpen, true, true},
+		{"probe cancelled", func() { b.cancelProbe(host) }, BreakerHalfOpen, true, true},
+		{"probe succeeded", func() { b.record(host, false, c) }, BreakerClosed, true, false},
+	}
+	for _, step := range steps {
+		step.do()
+		ok, probe, wait := b.allow(host, c)
+		if ok != step.allow || probe != step.probe {
+			t.Errorf("%s: Expected allow %v and probe %v, got %v and %v", step.name, step.allow, step.probe, ok, probe)
+		}
+		if !ok && wait <= 0 {
+			t.Errorf("%s: Expected a wait, got %s", step.name, wait)
+		}
+		if got := state(); got != step.state {
+			t.Errorf("%s: Expected state %s, got %s", step.name, step.state, got)
+		}
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	c := &Config{}
+	b := NewBreakerTable("foo")
+	for i := 0; i < 10; i++ {
+		b.record("example.com", true, c)
+	}
+	if ok, _, _ := b.allow("example.com", c); !ok {
+		t.Errorf("Expected no breaker without threshold")
+	}
+	if s := b.States(c); len(s) != 0 {
+		t.Errorf("Expected no breaker states, got %+v", s)
+	}
+}
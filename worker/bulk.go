@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/borgenk/qdo/log"
+)
+
+// BulkTask is a task of a bulk enqueue.
+type BulkTask struct {
+	Task           *Task
+	Scheduled      int64  // Unix time the task is due, 0 to add it waiting.
+	IdempotencyKey string // Empty unless the task is added once per key.
+}
+
+// BulkResult is the outcome of a task of a bulk enqueue. Task is the added
+// task, or the earlier one of the same idempotency key if Replayed, and nil
+// if Err is set.
+type BulkResult struct {
+	Task     *Task
+	Replayed bool
+	Err      error
+}
+
+// EnqueueBulk adds the tasks with a single store write. Each task is checked
+// as by Enqueue and one that fails is reported in its result without keeping
+// the others from being added. If the write fails it is reported for every
+// task that would have been added.
+func (q *QueueManager) EnqueueBulk(tasks []BulkTask) []BulkResult {
+	res := make([]BulkResult, len(tasks))
+
+	q.idempotencyMu.Lock()
+	defer q.idempotencyMu.Unlock()
+
+	b := q.db.NewBatch()
+	cutoff := q.idempotencyCutoff()
+	keys := map[string]*Task{} // Tasks staged per idempotency key.
+	ids := map[string]bool{}   // Ids given to staged tasks, not indexed until written.
+	staged := []int{}
+	var stagedSize int64
+	for i, t := range tasks {
+		if key := t.IdempotencyKey; key != "" {
+			if len(key) > 255 {
+				res[i].Err = ErrTaskInvalidKey
+				continue
+			}
+			existing := keys[key]
+			if existing == nil {
+				existing = q.idempotency.Get(key, cutoff)
+			}
+			if existing != nil {
+				log.Infof("queue/%s/task/%s - duplicate of idempotency key %q", q.ID, existing.ID, key)
+				res[i] = BulkResult{Task: existing, Replayed: true}
+				continue
+			}
+		}
+		task := t.Task
+		if ids[task.ID] {
+			res[i].Err = ErrTaskAlreadyExist
+			continue
+		}
+		err := q.prepareTask(task, int64(len(staged)), stagedSize)
+		if err != nil {
+			res[i].Err = err
+			continue
+		}
+		if t.Scheduled == 0 {
+			order, err := q.waitQueue.order(task)
+			if err != nil {
+				res[i].Err = err
+				continue
+			}
+			q.waitQueue.stage(b, task, order)
+		} else {
+			q.scheduleQueue.stage(b, task, strconv.FormatInt(t.Scheduled, 10))
+		}
+		if t.IdempotencyKey != "" {
+			q.idempotency.stage(b, t.IdempotencyKey, task)
+			keys[t.IdempotencyKey] = task
+		}
+		res[i].Task = task
+		ids[task.ID] = true
+		staged = append(staged, i)
+		stagedSize += int64(len(task.Payload))
+	}
+	if len(staged) == 0 {
+		return res
+	}
+
+	err := q.db.Write(b)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s - adding %d task(s) failed", q.ID, len(staged)), err)
+		for i := range res {
+			// Replays of tasks in the write fail along with them.
+			if res[i].Task != nil && keys[tasks[i].IdempotencyKey] == res[i].Task {
+				res[i] = BulkResult{Err: err}
+			}
+		}
+		for _, i := range staged {
+			res[i] = BulkResult{Err: err}
+		}
+		return res
+	}
+	for _, i := range staged {
+		if tasks[i].Scheduled == 0 {
+			q.waitQueue.added(res[i].Task)
+		} else {
+			q.scheduleQueue.added(res[i].Task)
+		}
+	}
+	q.stats.TotalReceived.Add(int64(len(staged)))
+	q.waitQueue.Trigger()
+	q.triggerTrim()
+	return res
+}
@@ -0,0 +1,371 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+const (
+	CronMissedSkip = "skip" // Drop runs missed while the daemon was down.
+	CronMissedOnce = "once" // Run once for all missed runs.
+	CronMissedAll  = "all"  // Run every missed run, up to MaxCronCatchUp.
+)
+
+const (
+	MaxCronCatchUp = 100
+	// cronLookahead is how far ahead occurrences are put in the schedule
+	// line, so they are dispatched on time.
+	cronLookahead = 5 * time.Second
+)
+
+var (
+	ErrCronNotFound        = errors.New("Cron error: cron job not found")
+	ErrCronAlreadyExist    = errors.New("Cron error: cron job already exist")
+	ErrCronInvalidID       = errors.New("Cron error: invalid id")
+	ErrCronInvalidTimezone = errors.New("Cron error: invalid timezone")
+	ErrCronNeverRuns       = errors.New("Cron error: schedule never runs")
+	ErrCronInvalidMissed   = errors.New("Cron error: invalid missed run policy")
+	ErrCronInvalidTTL      = errors.New("Cron error: invalid ttl")
+	ErrCronCorrupt         = errors.New("Cron error: stored cron job is corrupt")
+)
+
+// CronJob adds a task to its queue on a recurring schedule.
+type CronJob struct {
+	ID       string `json:"id"`
+	Schedule string `json:"schedule"` // Cron expression, see parseCronSchedule.
+	Timezone string `json:"timezone"` // IANA name the schedule is read in, empty for UTC.
+	Missed   string `json:"missed"`   // One of skip, once (default) or all.
+	TTL      int32  `json:"ttl"`      // Seconds each run may wait before it expires, 0 for never.
+	Task     Task   `json:"task"`     // Template of the task added for each run.
+	NextRun  int64  `json:"next_run"` // Unix time of the next run not yet added.
+}
+
+// Validate checks the job and normalizes its task template.
+func (j *CronJob) Validate() error {
+	if !validTaskID.MatchString(j.ID) {
+		return ErrCronInvalidID
+	}
+	schedule, err := parseCronSchedule(j.Schedule)
+	if err != nil {
+		return err
+	}
+	loc, err := j.location()
+	if err != nil {
+		return err
+	}
+	if schedule.Next(time.Now().In(loc)).IsZero() {
+		// i.e. 0 0 30 2 *
+		return ErrCronNeverRuns
+	}
+	switch j.Missed {
+	case "", CronMissedSkip, CronMissedOnce, CronMissedAll:
+	default:
+		return ErrCronInvalidMissed
+	}
+	if j.TTL < 0 {
+		return ErrCronInvalidTTL
+	}
+	return j.Task.Normalize()
+}
+
+func (j *CronJob) location() (*time.Location, error) {
+	loc, err := time.LoadLocation(j.Timezone)
+	if err != nil {
+		return nil, ErrCronInvalidTimezone
+	}
+	return loc, nil
+}
+
+// next returns the first run of the job after t.
+func (j *CronJob) next(t time.Time) time.Time {
+	schedule, err := parseCronSchedule(j.Schedule)
+	if err != nil {
+		return time.Time{}
+	}
+	loc, err := j.location()
+	if err != nil {
+		return time.Time{}
+	}
+	return schedule.Next(t.In(loc))
+}
+
+// CRON(4)|nextRun(8)|ttl(4)|sizeOfID(4)|id(x)|sizeOfSchedule(4)|schedule(x)|
+// sizeOfTimezone(4)|timezone(x)|sizeOfMissed(4)|missed(x)|task(x)
+func (j *CronJob) Serialize() []byte {
+	out := []byte("CRON")
+	out = appendUint64(out, uint64(j.NextRun))
+	out = appendUint32(out, uint32(j.TTL))
+	out = appendString(out, j.ID)
+	out = appendString(out, j.Schedule)
+	out = appendString(out, j.Timezone)
+	out = appendString(out, j.Missed)
+	out = append(out, j.Task.Serialize()...)
+	return out
+}
+
+func UnserializeCronJob(value []byte) (*CronJob, error) {
+	if !bytes.HasPrefix(value, []byte("CRON")) {
+		return nil, ErrCronCorrupt
+	}
+	j := &CronJob{}
+	nextRun, n := readUint64(value, 4)
+	j.NextRun = int64(nextRun)
+	ttl, n := readUint32(value, n)
+	j.TTL = int32(ttl)
+	j.ID, n = readString(value, n)
+	j.Schedule, n = readString(value, n)
+	j.Timezone, n = readString(value, n)
+	j.Missed, n = readString(value, n)
+	if n < 0 {
+		return nil, ErrCronCorrupt
+	}
+	task, err := UnserializeTask(nil, value[n:len(value)])
+	if err != nil {
+		return nil, ErrCronCorrupt
+	}
+	j.Task = *task
+	return j, nil
+}
+
+func NewCronTable(ID string, db store.Store, prefix, suffix []byte) *cronTable {
+	return &cronTable{
+		ID:     ID,
+		db:     db,
+		prefix: prefix,
+		suffix: suffix,
+	}
+}
+
+// cronTable stores the cron jobs of a queue.
+// Key format: [line id] \x00 [key type] \x00 [cron id]
+type cronTable struct {
+	ID     string
+	db     store.Store
+	prefix []byte
+	suffix []byte
+}
+
+func (c *cronTable) key(cronID string) []byte {
+	k := make([]byte, 0, len(c.prefix)+len(cronID))
+	k = append(k, c.prefix...)
+	return append(k, []byte(cronID)...)
+}
+
+func (c *cronTable) Get(cronID string) (*CronJob, error) {
+	k := c.key(cronID)
+	iter := c.db.NewIterator(nil)
+	defer iter.Close()
+	iter.Seek(k)
+	if !iter.Valid() || !bytes.Equal(iter.Key(), k) {
+		return nil, ErrCronNotFound
+	}
+	return UnserializeCronJob(append([]byte{}, iter.Value()...))
+}
+
+func (c *cronTable) GetAll() ([]CronJob, error) {
+	res := []CronJob{}
+	iter := c.db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(c.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), c.suffix) > 0 {
+			break
+		}
+		j, err := UnserializeCronJob(append([]byte{}, iter.Value()...))
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/cron - skipping job %q", c.ID, iter.Key()), err)
+			continue
+		}
+		res = append(res, *j)
+	}
+	return res, nil
+}
+
+func (c *cronTable) Put(job *CronJob) error {
+	err := c.db.Put(c.key(job.ID), job.Serialize())
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/cron/%s - storing failed", c.ID, job.ID), err)
+		return err
+	}
+	return nil
+}
+
+func (c *cronTable) Delete(cronID string) error {
+	return c.db.Delete(c.key(cronID))
+}
+
+// AddCronJob stores a new cron job. Its first run is the first match of the
+// schedule from now.
+func (q *QueueManager) AddCronJob(job *CronJob) error {
+	err := job.Validate()
+	if err != nil {
+		return err
+	}
+	err = q.checkTarget(&job.Task)
+	if err != nil {
+		return err
+	}
+	q.cronMu.Lock()
+	defer q.cronMu.Unlock()
+
+	if _, err := q.crons.Get(job.ID); err == nil {
+		return ErrCronAlreadyExist
+	}
+	job.NextRun = cronUnix(job.next(time.Now()))
+	log.Infof("queue/%s/cron/%s - adding, next run at %d", q.ID, job.ID, job.NextRun)
+	return q.crons.Put(job)
+}
+
+// UpdateCronJob replaces a cron job. Its next run is computed anew from now.
+func (q *QueueManager) UpdateCronJob(job *CronJob) error {
+	err := job.Validate()
+	if err != nil {
+		return err
+	}
+	err = q.checkTarget(&job.Task)
+	if err != nil {
+		return err
+	}
+	q.cronMu.Lock()
+	defer q.cronMu.Unlock()
+
+	if _, err := q.crons.Get(job.ID); err != nil {
+		return err
+	}
+	job.NextRun = cronUnix(job.next(time.Now()))
+	log.Infof("queue/%s/cron/%s - updating, next run at %d", q.ID, job.ID, job.NextRun)
+	return q.crons.Put(job)
+}
+
+func (q *QueueManager) GetCronJob(cronID string) (*CronJob, error) {
+	return q.crons.Get(cronID)
+}
+
+func (q *QueueManager) GetCronJobs() ([]CronJob, error) {
+	return q.crons.GetAll()
+}
+
+// DeleteCronJob removes a cron job. Runs already added stay in the queue.
+func (q *QueueManager) DeleteCronJob(cronID string) error {
+	q.cronMu.Lock()
+	defer q.cronMu.Unlock()
+
+	if _, err := q.crons.Get(cronID); err != nil {
+		return err
+	}
+	log.Infof("queue/%s/cron/%s - deleting", q.ID, cronID)
+	return q.crons.Delete(cronID)
+}
+
+// cron adds the runs of all cron jobs to the queue as they come due.
+func (q *QueueManager) cron() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.quit:
+			return
+		case <-ticker.C:
+		}
+		jobs, err := q.crons.GetAll()
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/cron - reading failed", q.ID), err)
+			continue
+		}
+		now := time.Now()
+		for i := range jobs {
+			if jobs[i].NextRun == 0 || jobs[i].NextRun > now.Add(cronLookahead).Unix() {
+				continue
+			}
+			q.cronMu.Lock()
+			// The job might have been changed or deleted since it was read.
+			if job, err := q.crons.Get(jobs[i].ID); err == nil {
+				err = q.runCronJob(job, now)
+				if err != nil {
+					log.Error(fmt.Sprintf("queue/%s/cron/%s - adding runs failed", q.ID, job.ID), err)
+				}
+			}
+			q.cronMu.Unlock()
+		}
+	}
+}
+
+// cronRun is a run of a cron job to add, at the given time and scheduled
+// then or right away if scheduled is zero.
+type cronRun struct {
+	at        time.Time
+	scheduled int64
+}
+
+// runCronJob adds the runs of the job due by now plus the lookahead, and
+// handles runs missed while the daemon was down by the job's missed policy.
+// The next run is stored before the runs are added, so they are not added
+// twice if storing fails. The caller holds cronMu.
+func (q *QueueManager) runCronJob(job *CronJob, now time.Time) error {
+	runs := []cronRun{}
+	next := time.Unix(job.NextRun, 0)
+	if next.Before(now) {
+		missed := 0
+		for !next.IsZero() && next.Before(now) && missed < MaxCronCatchUp {
+			missed++
+			next = job.next(next)
+		}
+		switch job.Missed {
+		case CronMissedSkip:
+			missed = 0
+		case CronMissedAll:
+		default:
+			if missed > 1 {
+				missed = 1
+			}
+		}
+		log.Infof("queue/%s/cron/%s - missed runs since %d, adding %d", q.ID, job.ID, job.NextRun, missed)
+		for i := 0; i < missed; i++ {
+			runs = append(runs, cronRun{now, 0})
+		}
+		// Runs beyond the catch up limit are dropped.
+		next = job.next(now)
+	}
+	for !next.IsZero() && !next.After(now.Add(cronLookahead)) {
+		runs = append(runs, cronRun{next, next.Unix()})
+		next = job.next(next)
+	}
+	job.NextRun = cronUnix(next)
+	err := q.crons.Put(job)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		q.addCronRun(job, run.at, run.scheduled)
+	}
+	return nil
+}
+
+// cronUnix returns the unix time of a run, 0 for the zero time of no run.
+func cronUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// addCronRun adds one run of the job, scheduled at the given unix time or
+// right away if zero.
+func (q *QueueManager) addCronRun(job *CronJob, at time.Time, scheduled int64) {
+	task := job.Task
+	task.ID = ""
+	task.Key = nil
+	if job.TTL > 0 {
+		task.ExpiresAt = at.Unix() + int64(job.TTL)
+	}
+	res, err := q.Enqueue(&task, scheduled)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/cron/%s - adding run failed", q.ID, job.ID), err)
+		return
+	}
+	log.Infof("queue/%s/cron/%s - added run as task %s", q.ID, job.ID, res.ID)
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Thursday.
+	from := time.Date(2014, 5, 15, 10, 30, 20, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2014, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", at(5, 15, 10, 31)},
+		{"*/15 * * * *", at(5, 15, 10, 45)},
+		{"5/15 * * * *", at(5, 15, 10, 35)},
+		{"0 9-17 * * mon-fri", at(5, 15, 11, 0)},
+		{"0 0 * * *", at(5, 16, 0, 0)},
+		{"@hourly", at(5, 15, 11, 0)},
+		{"@weekly", at(5, 18, 0, 0)},
+		{"@monthly", at(6, 1, 0, 0)},
+		{"0 0 1 jan *", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", at(5, 18, 12, 0)},
+		{"0 0 29 2 *", time.Date(2016, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Either restricted day field matching is enough.
+		{"0 0 20 * fri", at(5, 16, 0, 0)},
+		// A step over * leaves its field unrestricted.
+		{"0 0 */2 * fri", at(5, 23, 0, 0)},
+		{"0 0 1 * */2", at(6, 1, 0, 0)},
+		{"0 0 30 2 *", time.Time{}},
+		{"0 0 31 4,6,9,11 *", time.Time{}},
+	}
+	for _, test := range tests {
+		s, err := parseCronSchedule(test.expr)
+		if err != nil {
+			t.Errorf("%s: Expected no error, got %s", test.expr, err)
+			continue
+		}
+		got := s.Next(from)
+		if !got.Equal(test.want) {
+			t.Errorf("%s: Expected %s, got %s", test.expr, test.want, got)
+		}
+	}
+}
+
+func TestCronScheduleNextTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	s, _ := parseCronSchedule("30 2 * * *")
+	// Clocks go from 02:00 to 03:00 on March 30th 2014, that day has no 02:30.
+	got := s.Next(time.Date(2014, 3, 29, 12, 0, 0, 0, loc))
+	want := time.Date(2014, 3, 31, 2, 30, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "foo * * * *",
+	} {
+		if _, err := parseCronSchedule(expr); err != ErrCronInvalidSchedule {
+			t.Errorf("%q: Expected ErrCronInvalidSchedule, got %v", expr, err)
+		}
+	}
+}
+
+func TestCronJobValidateNeverRuns(t *testing.T) {
+	job := &CronJob{ID: "feb30", Schedule: "0 0 30 2 *", Task: Task{Target: "http://127.0.0.1/"}}
+	if err := job.Validate(); err != ErrCronNeverRuns {
+		t.Errorf("Expected ErrCronNeverRuns, got %v", err)
+	}
+}
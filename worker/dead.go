@@ -0,0 +1,154 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+// DeadTask is a task that will not be retried any more, together with the
+// reason it was given up on.
+type DeadTask struct {
+	Task
+	Reason string `json:"reason"`
+	DiedAt int64  `json:"died_at"`
+}
+
+// DEAD(4)|diedAt(8)|sizeOfReason(4)|reason(x)|task(x)
+func (d *DeadTask) Serialize() []byte {
+	var (
+		out          []byte
+		diedAt       []byte = make([]byte, 8)
+		sizeOfReason []byte = make([]byte, 4)
+	)
+
+	binary.LittleEndian.PutUint64(diedAt, uint64(d.DiedAt))
+	binary.LittleEndian.PutUint32(sizeOfReason, uint32(len(d.Reason)))
+
+	out = append([]byte("DEAD"), diedAt...)
+	out = append(out, sizeOfReason...)
+	out = append(out, []byte(d.Reason)...)
+	out = append(out, d.Task.Serialize()...)
+	return out
+}
+
+func UnserializeDeadTask(key, value []byte) (*DeadTask, error) {
+	if !bytes.HasPrefix(value, []byte("DEAD")) {
+		return nil, ErrTaskCorrupt
+	}
+	diedAt, n := readUint64(value, 4)
+	reason, n := readString(value, n)
+	if n < 0 {
+		return nil, ErrTaskCorrupt
+	}
+	task, err := UnserializeTask(key, value[n:len(value)])
+	if err != nil {
+		return nil, err
+	}
+	return &DeadTask{
+		Task:   *task,
+		DiedAt: int64(diedAt),
+		Reason: reason,
+	}, nil
+}
+
+func NewDeadQueue(ID string, config *Config, total *AtomicInt, db store.Store, notifySignal chan systemSignal, prefix, suffix []byte) *deadQueue {
+	dq := &deadQueue{
+		queueLine: queueLine{
+			ID:           ID,
+			Type:         "dead",
+			config:       config,
+			db:           db,
+			notifySignal: notifySignal,
+			prefix:       prefix,
+			suffix:       suffix,
+			total:        total,
+		},
+	}
+	return dq
+}
+
+// deadQueue holds tasks that exhausted their retries or were rejected by the
+// target. Tasks stay here until they are requeued or purged.
+type deadQueue struct {
+	queueLine
+}
+
+func (d *deadQueue) Add(task *Task, reason error) error {
+	log.Infof("queue/%s/%s/task/%s - adding: %s", d.ID, d.Type, task.ID, reason)
+
+	now := time.Now().Unix()
+	dead := &DeadTask{
+		Task:   *task,
+		Reason: reason.Error(),
+		DiedAt: now,
+	}
+	dead.Key = d.key(task, strconv.FormatInt(now, 10))
+	err := d.db.Put(dead.Key, dead.Serialize())
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/%s/task/%s - adding failed", d.ID, d.Type, task.ID), err)
+		return err
+	}
+	d.total.Add(1)
+	return nil
+}
+
+// Get returns the dead task with the given id, or ErrTaskNotFound.
+func (d *deadQueue) Get(taskID string) (*DeadTask, error) {
+	var res *DeadTask
+	err := d.each(func(dead *DeadTask) bool {
+		if dead.ID == taskID {
+			res = dead
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ErrTaskNotFound
+	}
+	return res, nil
+}
+
+func (d *deadQueue) GetAll() (*[]DeadTask, error) {
+	limit := 100
+	result := []DeadTask{}
+	err := d.each(func(dead *DeadTask) bool {
+		result = append(result, *dead)
+		return len(result) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// each calls fn on every dead task, oldest first, until fn returns false.
+func (d *deadQueue) each(fn func(*DeadTask) bool) error {
+	iter := d.db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(d.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), d.suffix) > 0 {
+			break
+		}
+		// Copy key and value since the iterator may reuse its buffers.
+		k := append([]byte{}, iter.Key()...)
+		v := append([]byte{}, iter.Value()...)
+		dead, err := UnserializeDeadTask(k, v)
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/%s - skipping task %q", d.ID, d.Type, k), err)
+			continue
+		}
+		if !fn(dead) {
+			break
+		}
+	}
+	return nil
+}
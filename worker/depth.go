@@ -0,0 +1,180 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+)
+
+const (
+	OverflowReject     = "reject"
+	OverflowDropOldest = "drop-oldest"
+)
+
+var (
+	ErrQueueFull              = errors.New("Queue error: too many pending tasks")
+	ErrConfigInvalidDepth     = errors.New("Config error: invalid max pending")
+	ErrConfigInvalidOverflow  = errors.New("Config error: invalid overflow policy")
+	ErrConfigInvalidDepthSize = errors.New("Config error: invalid max pending bytes")
+)
+
+// validateDepth checks the limits on pending tasks.
+func (c *Config) validateDepth() error {
+	if c.MaxPending < 0 {
+		return ErrConfigInvalidDepth
+	}
+	if c.MaxPendingBytes < 0 {
+		return ErrConfigInvalidDepthSize
+	}
+	switch c.Overflow {
+	case "", OverflowReject, OverflowDropOldest:
+	default:
+		return ErrConfigInvalidOverflow
+	}
+	return nil
+}
+
+// overLimit reports whether the queue would hold more pending tasks or
+// payload bytes than allowed after adding n tasks of size bytes. Pending tasks
+// are the waiting, processing and scheduled ones.
+func (q *QueueManager) overLimit(c *Config, n, size int64) bool {
+	if c.MaxPending > 0 && q.stats.InQueue.Get()+q.stats.InScheduled.Get()+n > int64(c.MaxPending) {
+		return true
+	}
+	if c.MaxPendingBytes > 0 && q.stats.PendingBytes.Get()+size > c.MaxPendingBytes {
+		return true
+	}
+	return false
+}
+
+// checkDepth decides whether a task may be added to the queue, after the
+// given number of tasks of size bytes not yet counted. A full queue rejects
+// it, unless it drops its oldest tasks instead.
+func (q *QueueManager) checkDepth(task *Task, staged, stagedSize int64) error {
+	c, _ := q.getConfig()
+	size := int64(len(task.Payload))
+	if c.MaxPendingBytes > 0 && size > c.MaxPendingBytes {
+		// Would not fit even in an empty queue.
+		q.stats.TotalRejected.Add(1)
+		return ErrQueueFull
+	}
+	if c.Overflow != OverflowDropOldest && q.overLimit(c, staged+1, stagedSize+size) {
+		q.stats.TotalRejected.Add(1)
+		return ErrQueueFull
+	}
+	return nil
+}
+
+// FullRetryAfter is how long clients are told to wait before adding to a full
+// queue again, the time it takes to dispatch one task at the queue's rate.
+func (q *QueueManager) FullRetryAfter() time.Duration {
+	c, _ := q.getConfig()
+	if rate := c.Rate(); rate > 0 && rate < 1 {
+		return time.Duration(float64(time.Second) / rate)
+	}
+	return time.Second
+}
+
+// triggerTrim wakes up the trimmer after a task was added. A trigger while
+// the trimmer is busy is remembered.
+func (q *QueueManager) triggerTrim() {
+	select {
+	case q.trimSignal <- struct{}{}:
+	default:
+	}
+}
+
+// trimmer drops the oldest pending tasks of a queue with the drop-oldest
+// policy once new tasks take it over its limits. It runs apart from Enqueue
+// as dropping settles batches and pipelines, whose locks the callers of
+// Enqueue might hold.
+func (q *QueueManager) trimmer() {
+	for {
+		select {
+		case <-q.quit:
+			return
+		case <-q.trimSignal:
+		}
+		q.trim()
+	}
+}
+
+func (q *QueueManager) trim() {
+	c, _ := q.getConfig()
+	if c.Overflow != OverflowDropOldest {
+		return
+	}
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+	q.taskMu.Lock()
+	defer q.taskMu.Unlock()
+
+	for q.overLimit(c, 0, 0) {
+		task, line := q.oldestPending()
+		if task == nil {
+			return
+		}
+		if task.BatchID != "" {
+			q.batchTaskDone(task, false)
+		}
+		err := line.Delete(task.Key)
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/task/%s - dropping failed", q.ID, task.ID), err)
+			return
+		}
+		log.Infof("queue/%s/task/%s - queue full, dropped", q.ID, task.ID)
+		q.stats.TotalDropped.Add(1)
+		if task.PipelineID != "" {
+			q.failPipeline(task, ErrQueueFull)
+		}
+	}
+}
+
+// oldestPending returns the task that has waited longest, or if none is
+// waiting the scheduled task due first. Tasks being processed or leased are
+// passed over. The caller holds leaseMu and taskMu.
+func (q *QueueManager) oldestPending() (*Task, *queueLine) {
+	var oldest []byte
+	for priority := MinTaskPriority; priority <= MaxTaskPriority; priority++ {
+		prefix := q.waitQueue.bandPrefix(priority)
+		iter := q.db.NewIterator(nil)
+		for iter.Seek(prefix); iter.Valid() && bytes.HasPrefix(iter.Key(), prefix); iter.Next() {
+			if q.processing[string(iter.Key())] {
+				continue
+			}
+			// Orders within bands start with the time the task was added.
+			if oldest == nil || bytes.Compare(iter.Key()[len(prefix):], oldest[len(prefix):]) < 0 {
+				oldest = append([]byte{}, iter.Key()...)
+			}
+			break
+		}
+		iter.Close()
+	}
+	if oldest != nil {
+		task, err := q.waitQueue.getKey(oldest)
+		if err == nil {
+			return task, &q.waitQueue.queueLine
+		}
+	}
+
+	iter := q.db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(q.scheduleQueue.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), q.scheduleQueue.suffix) > 0 {
+			break
+		}
+		if q.scheduleQueue.isLeaseKey(iter.Key()) {
+			continue
+		}
+		k := append([]byte{}, iter.Key()...)
+		task, err := UnserializeTask(k, append([]byte{}, iter.Value()...))
+		if err != nil {
+			continue
+		}
+		return task, &q.scheduleQueue.queueLine
+	}
+	return nil, nil
+}
@@ -0,0 +1,249 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+)
+
+// ExecScheme is the target scheme of tasks run as a local command. The host
+// of the target names one of the queue's commands, i.e. exec://resize.
+const ExecScheme = "exec"
+
+// MaxCommandOutput is the maximum number of bytes of stdout and of stderr
+// kept per run.
+const MaxCommandOutput = AttemptResponseLimit
+
+var (
+	ErrConfigInvalidCommand = errors.New("Config error: invalid command, give a name and an absolute path")
+	ErrConfigInvalidExit    = errors.New("Config error: invalid exit code")
+	ErrConfigUnknownCommand = errors.New("Config error: unknown command, give commands configured when starting the daemon")
+	ErrCommandNotFound      = errors.New("Command error: command not configured for queue")
+)
+
+var validCommandName = regexp.MustCompile("^[a-z0-9][a-z0-9_.-]{0,63}$")
+
+// commands is the allowlist of programs exec:// targets may run, by name. It
+// is set by the daemon only, never through the API. Each queue runs only the
+// commands its configuration lists.
+var commands = map[string][]string{}
+
+// SetCommands sets the programs exec:// targets may run, each given as
+// "name /path/to/program args...".
+func SetCommands(lines []string) error {
+	res := map[string][]string{}
+	for _, line := range lines {
+		name, argv, err := ParseCommand(line)
+		if err != nil {
+			return err
+		}
+		if !validCommandName.MatchString(name) || !filepath.IsAbs(argv[0]) {
+			return ErrConfigInvalidCommand
+		}
+		res[name] = argv
+	}
+	commands = res
+	return nil
+}
+
+// validateExitCodes checks the exit codes of commands not retried.
+func (c *Config) validateExitCodes() error {
+	for _, code := range c.ExitNoRetry {
+		if code < 1 || code > 255 {
+			return ErrConfigInvalidExit
+		}
+	}
+	return nil
+}
+
+// validateCommands checks that the queue only lists commands of the daemon.
+func (c *Config) validateCommands() error {
+	for _, name := range c.Commands {
+		if _, ok := commands[name]; !ok {
+			return ErrConfigUnknownCommand
+		}
+	}
+	return nil
+}
+
+// command returns the program an exec target of the queue runs, or false if
+// the queue or the daemon does not allow it.
+func (c *Config) command(target string) ([]string, bool) {
+	name := commandName(target)
+	for _, allowed := range c.Commands {
+		if allowed == name {
+			argv, ok := commands[name]
+			return argv, ok
+		}
+	}
+	return nil, false
+}
+
+// ParseCommand reads a command given as "name /path/to/program args...".
+// Arguments are separated by white space and can not be quoted.
+func ParseCommand(value string) (string, []string, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return "", nil, ErrConfigInvalidCommand
+	}
+	return fields[0], fields[1:], nil
+}
+
+// CommandLines returns the allowed commands as given to ParseCommand, sorted
+// by name.
+func CommandLines() []string {
+	res := make([]string, 0, len(commands))
+	for name, argv := range commands {
+		res = append(res, name+" "+strings.Join(argv, " "))
+	}
+	sort.Strings(res)
+	return res
+}
+
+// isExecTarget reports whether the target is run as a local command.
+func isExecTarget(target string) bool {
+	return strings.HasPrefix(target, ExecScheme+"://")
+}
+
+// commandName returns the command an exec target runs.
+func commandName(target string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// execExecutor runs tasks as local commands the queue allows.
+type execExecutor struct {
+	queueID string
+	config  *Config
+}
+
+func newExecExecutor(queueID string, c *Config) (Executor, error) {
+	err := CheckExecutorOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	return &execExecutor{queueID: queueID, config: c}, nil
+}
+
+// Execute runs the command named by the target with the payload on stdin.
+// Exit code 0 is success, exit codes listed in ExitNoRetry fail and all other
+// failures are retried. The command and every process it started are killed
+// once the task timeout passes.
+func (e *execExecutor) Execute(t *Task) *Result {
+	name := commandName(t.Target)
+	argv, ok := e.config.command(t.Target)
+	if !ok {
+		return &Result{Outcome: OutcomeFail, Err: ErrCommandNotFound}
+	}
+
+	stdout := &cappedBuffer{limit: t.ResponseLimit()}
+	stderr := &cappedBuffer{limit: MaxCommandOutput}
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = strings.NewReader(t.Payload)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), t.commandEnv(e.queueID)...)
+	setProcessGroup(cmd)
+
+	err := cmd.Start()
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/task/%s - starting command %s failed", e.queueID, t.ID, name), err)
+		return &Result{Outcome: OutcomeRetry, Err: err}
+	}
+	var timer *time.Timer
+	if e.config.TaskTimeout > 0 {
+		timer = time.AfterFunc(time.Duration(e.config.TaskTimeout)*time.Second, func() {
+			killProcessGroup(cmd)
+		})
+	}
+	err = cmd.Wait()
+	timedOut := timer != nil && !timer.Stop()
+
+	res := &Result{
+		Outcome:  OutcomeSuccess,
+		Status:   exitCode(err),
+		Response: stdout.Bytes(),
+	}
+	if err == nil {
+		return res
+	}
+	res.Outcome = OutcomeRetry
+	msg := err.Error()
+	if timedOut {
+		msg = fmt.Sprintf("timed out after %ds", e.config.TaskTimeout)
+	}
+	if stderr.Len() > 0 {
+		msg += ": " + stderr.String()
+	}
+	res.Err = errors.New(msg)
+	for _, c := range e.config.ExitNoRetry {
+		if c == res.Status && !timedOut {
+			res.Outcome = OutcomeFail
+		}
+	}
+	return res
+}
+
+func (e *execExecutor) Close() {}
+
+// commandEnv returns the task metadata passed to a command.
+func (t *Task) commandEnv(queueID string) []string {
+	return []string{
+		"QDO_QUEUE_ID=" + queueID,
+		"QDO_TASK_ID=" + t.ID,
+		"QDO_TARGET=" + t.Target,
+		"QDO_CONTENT_TYPE=" + t.ContentType,
+		"QDO_TRIES=" + strconv.Itoa(int(t.Tries)),
+		"QDO_PRIORITY=" + strconv.Itoa(int(t.Priority)),
+		"QDO_EXPIRES_AT=" + strconv.FormatInt(t.ExpiresAt, 10),
+		"QDO_PIPELINE_ID=" + t.PipelineID,
+		"QDO_PIPELINE_STAGE=" + strconv.Itoa(int(t.Stage)),
+		"QDO_BATCH_ID=" + t.BatchID,
+	}
+}
+
+// exitCode returns the exit code of a finished command, -1 if it did not
+// exit by itself.
+func exitCode(err error) int32 {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Exited() {
+			return int32(status.ExitStatus())
+		}
+	}
+	return -1
+}
+
+// cappedBuffer keeps the first limit bytes written to it and drops the rest.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
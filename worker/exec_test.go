@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"testing"
+)
+
+func TestQueueCommands(t *testing.T) {
+	defer func(p *TargetPolicy) { globalTarget = p }(globalTarget)
+	defer func(c map[string][]string) { commands = c }(commands)
+	globalTarget = &TargetPolicy{Schemes: []string{"http", ExecScheme}}
+	err := SetCommands([]string{"backup /bin/true", "report /bin/true"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	queues := map[string]*Config{
+		"a": {MaxConcurrent: 1, Commands: []string{"backup"}},
+		"b": {MaxConcurrent: 1, Commands: []string{"report"}},
+		"c": {MaxConcurrent: 1},
+	}
+	for _, c := range queues {
+		c.Target.Schemes = []string{ExecScheme}
+		if err := c.Validate(); err != nil {
+			t.Fatalf("Expected valid config %+v, got %s", c, err)
+		}
+	}
+	tests := []struct {
+		queue  string
+		target string
+		err    error
+	}{
+		{"a", "exec://backup", nil},
+		{"a", "exec://report", ErrCommandNotFound},
+		{"b", "exec://report", nil},
+		{"b", "exec://backup", ErrCommandNotFound},
+		{"c", "exec://backup", ErrCommandNotFound},
+		{"a", "exec://missing", ErrCommandNotFound},
+	}
+	for _, test := range tests {
+		c := queues[test.queue]
+		task := &Task{ID: "x", Target: test.target}
+		q := &QueueManager{ID: test.queue, Config: c}
+		if err := q.checkTarget(task); err != test.err {
+			t.Errorf("Expected %v enqueueing %s to %s, got %v", test.err, test.target, test.queue, err)
+		}
+
+		// Tasks already queued are checked again when run.
+		res := (&execExecutor{queueID: test.queue, config: c}).Execute(task)
+		if test.err == nil && res.Outcome != OutcomeSuccess {
+			t.Errorf("Expected %s to run %s, got %+v", test.queue, test.target, res)
+		}
+		if test.err != nil && (res.Outcome != OutcomeFail || res.Err != test.err) {
+			t.Errorf("Expected %s to refuse %s, got %+v", test.queue, test.target, res)
+		}
+	}
+
+	c := &Config{MaxConcurrent: 1, Commands: []string{"backup", "missing"}}
+	if err := c.Validate(); err != ErrConfigUnknownCommand {
+		t.Errorf("Expected ErrConfigUnknownCommand, got %v", err)
+	}
+}
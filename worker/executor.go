@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Outcome classifies the result of delivering a task.
+type Outcome int
+
+const (
+	OutcomeSuccess    Outcome = iota // Delivered, the task is done.
+	OutcomeRetry      Outcome = iota // Failed, retry by the queue's retry policy.
+	OutcomeFail       Outcome = iota // Failed for good, move the task to the dead queue.
+	OutcomeRetryAfter Outcome = iota // Target asked to retry after Result.RetryAfter.
+)
+
+// Result is what an executor reports back for one delivery of a task.
+type Result struct {
+	Outcome     Outcome
+	RetryAfter  time.Duration // Wait asked for with OutcomeRetryAfter.
+	Status      int32         // Status reported by the target, i.e. HTTP status or exit code, 0 if none.
+	Response    []byte        // Output of the target, up to Task.ResponseLimit bytes.
+	ContentType string        // Content type of Response, empty if unknown.
+	Err         error         // Why delivery did not succeed, if known.
+}
+
+// Executor delivers tasks to their target. An executor is created for every
+// configuration of a queue and may be used by many tasks at once.
+type Executor interface {
+	Execute(task *Task) *Result
+	// Close releases the resources of the executor once the queue has moved
+	// on to a new configuration. Tasks still running may continue to use it.
+	Close()
+}
+
+// ExecutorConstructor creates an executor for a queue from its configuration,
+// including its ExecutorOptions. Options it does not know are rejected, see
+// CheckExecutorOptions.
+type ExecutorConstructor func(queueID string, config *Config) (Executor, error)
+
+var executors = map[string]ExecutorConstructor{}
+
+var ErrExecutorNotFound = errors.New("Executor error: no executor for target")
+
+// schemeExecutors maps target schemes to the executor handling them when the
+// executor of a queue is picked by scheme.
+var schemeExecutors = map[string]string{
+	"https": "http",
+}
+
+func init() {
+	RegisterExecutor("http", newHTTPExecutor)
+	RegisterExecutor(ExecScheme, newExecExecutor)
+}
+
+// RegisterExecutor makes an executor available to queues under the given
+// name. Register executors before starting the controller.
+func RegisterExecutor(name string, NewExecutor ExecutorConstructor) {
+	executors[name] = NewExecutor
+}
+
+func GetExecutorConstructor(name string) (ExecutorConstructor, bool) {
+	c, ok := executors[name]
+	return c, ok
+}
+
+// ExecutorNames returns the names of all registered executors.
+func ExecutorNames() []string {
+	names := make([]string, 0, len(executors))
+	for name := range executors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OptionLines returns the executor options of the configuration as
+// key=value, sorted by key.
+func (c *Config) OptionLines() []string {
+	res := make([]string, 0, len(c.ExecutorOptions))
+	for k, v := range c.ExecutorOptions {
+		res = append(res, k+"="+v)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// CheckExecutorOptions returns ErrConfigInvalidOption if the configuration
+// has executor options other than the given ones.
+func CheckExecutorOptions(c *Config, known ...string) error {
+	for k := range c.ExecutorOptions {
+		ok := false
+		for _, name := range known {
+			ok = ok || k == name
+		}
+		if !ok {
+			return ErrConfigInvalidOption
+		}
+	}
+	return nil
+}
+
+// schemeExecutorNames returns the registered executors of the schemes that
+// both the global and the queue's target policy allow, sorted.
+func schemeExecutorNames(c *Config) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, scheme := range c.Target.schemes() {
+		if !globalTarget.allowsScheme(scheme) {
+			continue
+		}
+		name := scheme
+		if alias, ok := schemeExecutors[name]; ok {
+			name = alias
+		}
+		if _, ok := executors[name]; !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// executorSet holds the executors of one queue configuration. A queue with
+// no executor configured picks one by the scheme of each target and gets one
+// executor for each scheme it may deliver to. Executor options are only
+// given with an executor configured.
+type executorSet struct {
+	config    *Config
+	executors map[string]Executor
+}
+
+func newExecutorSet(queueID string, c *Config) (*executorSet, error) {
+	names := []string{c.Executor}
+	if c.Executor == "" {
+		if len(c.ExecutorOptions) > 0 {
+			return nil, ErrConfigInvalidOption
+		}
+		names = schemeExecutorNames(c)
+	}
+	s := &executorSet{
+		config:    c,
+		executors: make(map[string]Executor, len(names)),
+	}
+	for _, name := range names {
+		newExecutor, ok := executors[name]
+		if !ok {
+			s.Close()
+			return nil, ErrConfigInvalidExecutor
+		}
+		e, err := newExecutor(queueID, c)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		s.executors[name] = e
+	}
+	return s, nil
+}
+
+// get returns the executor delivering to target, nil if there is none.
+func (s *executorSet) get(target string) Executor {
+	name := s.config.Executor
+	if name == "" {
+		u, err := url.Parse(target)
+		if err != nil {
+			return nil
+		}
+		name = u.Scheme
+		if alias, ok := schemeExecutors[name]; ok {
+			name = alias
+		}
+	}
+	return s.executors[name]
+}
+
+func (s *executorSet) Close() {
+	for _, e := range s.executors {
+		e.Close()
+	}
+}
@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// httpExecutor sends tasks as HTTP requests to their target.
+type httpExecutor struct {
+	client  *http.Client
+	secrets []string
+}
+
+func newHTTPExecutor(queueID string, c *Config) (Executor, error) {
+	err := CheckExecutorOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(c.TaskTimeout) * time.Second
+	policy := &c.Target
+	transport := http.Transport{
+		Dial: func(network, addr string) (net.Conn, error) {
+			return dialTarget(network, addr, timeout, policy)
+		},
+		Proxy:                 http.ProxyFromEnvironment,
+		ResponseHeaderTimeout: timeout,
+	}
+	if globalTarget.restrictsHosts() || policy.restrictsHosts() {
+		// A proxy would connect to the target on our behalf, unchecked.
+		transport.Proxy = nil
+	}
+	return &httpExecutor{
+		client: &http.Client{
+			Transport: &transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 10 {
+					return errors.New("stopped after 10 redirects")
+				}
+				return checkTarget(&Task{Target: req.URL.String()}, policy)
+			},
+		},
+		secrets: c.SigningSecrets,
+	}, nil
+}
+
+// Execute sends the task. 2xx responses are success, 429 and 503 with a
+// Retry-After header are retried when asked, other 4xx responses and tasks
+// that do not make a valid request fail and everything else is retried.
+func (e *httpExecutor) Execute(t *Task) *Result {
+	req, err := t.newRequest()
+	if err != nil {
+		return &Result{Outcome: OutcomeFail, Err: ErrTaskInvalidTarget}
+	}
+	if len(e.secrets) > 0 {
+		timestamp := time.Now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, Sign(e.secrets, timestamp, []byte(t.Payload)))
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		if isTargetDenied(err) {
+			// Resolved to an address the target policy denies, or
+			// redirected out of it.
+			return &Result{Outcome: OutcomeFail, Err: ErrTargetHostDenied}
+		}
+		// Connection failure, retry later.
+		return &Result{Outcome: OutcomeRetry, Err: err}
+	}
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, int64(t.ResponseLimit())))
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	res := &Result{
+		Outcome:     OutcomeRetry,
+		Status:      int32(resp.StatusCode),
+		Response:    body,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		res.Outcome = OutcomeSuccess
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			res.Outcome = OutcomeRetryAfter
+			res.RetryAfter = d
+		}
+	case resp.StatusCode >= 400 && resp.StatusCode <= 499:
+		res.Outcome = OutcomeFail
+		res.Err = ErrClientBadRequest
+	}
+	return res
+}
+
+func (e *httpExecutor) Close() {
+	if t, ok := e.client.Transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+}
+
+// newRequest builds the HTTP request delivering the task to its target.
+func (t *Task) newRequest() (*http.Request, error) {
+	method := t.Method
+	if method == "" {
+		method = DefaultTaskMethod
+	}
+	var body io.Reader
+	if t.Payload != "" || method != "GET" {
+		body = strings.NewReader(t.Payload)
+	}
+	req, err := http.NewRequest(method, t.Target, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range t.Headers {
+		if http.CanonicalHeaderKey(k) == "Host" {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	if body != nil {
+		contentType := t.ContentType
+		if contentType == "" {
+			contentType = DefaultTaskContentType
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// parseRetryAfter parses a Retry-After header value given either as a number
+// of seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	d := date.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2014, 5, 13, 16, 53, 20, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{"Tue, 13 May 2014 16:54:20 GMT", time.Minute, true},
+		{"Tue, 13 May 2014 16:52:20 GMT", 0, true},
+		{"Tuesday, 13-May-14 16:54:20 GMT", time.Minute, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"1.5", 0, false},
+		{"soon", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := parseRetryAfter(test.value, now)
+		if got != test.want || ok != test.ok {
+			t.Errorf("Expected %s, %v for %q, got %s, %v", test.want, test.ok, test.value, got, ok)
+		}
+	}
+}
+
+func TestHTTPExecutorInvalidRequest(t *testing.T) {
+	e, err := newHTTPExecutor("foo", &Config{TaskTimeout: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	defer e.Close()
+	for _, task := range []*Task{{Target: "http://[::1/"}, {Target: "http://127.0.0.1/", Method: "BAD METHOD"}} {
+		res := e.Execute(task)
+		if res.Outcome != OutcomeFail || res.Err != ErrTaskInvalidTarget {
+			t.Errorf("Expected %s to fail with ErrTaskInvalidTarget, got %+v", task.Target, res)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchemeExecutorNames(t *testing.T) {
+	defer func(p *TargetPolicy) { globalTarget = p }(globalTarget)
+	tests := []struct {
+		global []string
+		queue  []string
+		want   []string
+	}{
+		{nil, nil, []string{"http"}},
+		{nil, []string{"https"}, []string{"http"}},
+		{nil, []string{"exec"}, []string{}},
+		{[]string{"http", "exec"}, []string{"http", "exec"}, []string{"exec", "http"}},
+		{[]string{"http", "exec"}, nil, []string{"http"}},
+		{[]string{"exec", "ftp"}, []string{"exec", "ftp"}, []string{"exec"}},
+	}
+	for _, test := range tests {
+		globalTarget = &TargetPolicy{Schemes: test.global}
+		c := &Config{Target: TargetPolicy{Schemes: test.queue}}
+		got := schemeExecutorNames(c)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Expected %v for %v within %v, got %v", test.want, test.queue, test.global, got)
+		}
+	}
+}
+
+func TestExecutorOptions(t *testing.T) {
+	tests := []struct {
+		executor string
+		options  map[string]string
+		err      error
+	}{
+		{"", nil, nil},
+		{"http", nil, nil},
+		{ExecScheme, map[string]string{}, nil},
+		{"", map[string]string{"region": "eu-west-1"}, ErrConfigInvalidOption},
+		{"http", map[string]string{"region": "eu-west-1"}, ErrConfigInvalidOption},
+		{ExecScheme, map[string]string{"shell": "sh"}, ErrConfigInvalidOption},
+		{"sqs", nil, ErrConfigInvalidExecutor},
+	}
+	for _, test := range tests {
+		c := &Config{Executor: test.executor, ExecutorOptions: test.options}
+		s, err := newExecutorSet("foo", c)
+		if err != test.err {
+			t.Errorf("Expected %v for %q with %v, got %v", test.err, test.executor, test.options, err)
+		}
+		if s != nil {
+			s.Close()
+		}
+	}
+
+	c := &Config{ExecutorOptions: map[string]string{"region": "eu-west-1"}}
+	if err := CheckExecutorOptions(c, "queue", "region"); err != nil {
+		t.Errorf("Expected known options to pass, got %v", err)
+	}
+}
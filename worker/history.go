@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/borgenk/qdo/config"
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+// AttemptResponseLimit is the maximum number of response body bytes kept per
+// attempt.
+const AttemptResponseLimit = 1024
+
+var ErrAttemptCorrupt = errors.New("History error: stored attempt is corrupt")
+
+// Attempt is the result of a single delivery of a task to its target.
+type Attempt struct {
+	StartedAt time.Time `json:"started_at"`
+	Duration  int64     `json:"duration"` // Milliseconds.
+	Status    int32     `json:"status"`   // Status reported by the target, i.e. HTTP status or exit code, 0 if none.
+	Error     string    `json:"error"`
+	Response  string    `json:"response"` // Response body, truncated to AttemptResponseLimit bytes.
+}
+
+// ATTP(4)|startedAt(8)|duration(8)|status(4)|sizeOfError(4)|error(x)|response(x)
+func (a *Attempt) Serialize() []byte {
+	var (
+		startedAt []byte = make([]byte, 8)
+		duration  []byte = make([]byte, 8)
+	)
+
+	binary.LittleEndian.PutUint64(startedAt, uint64(a.StartedAt.UnixNano()))
+	binary.LittleEndian.PutUint64(duration, uint64(a.Duration))
+
+	out := append([]byte("ATTP"), startedAt...)
+	out = append(out, duration...)
+	out = appendUint32(out, uint32(a.Status))
+	out = appendString(out, a.Error)
+	out = append(out, []byte(a.Response)...)
+	return out
+}
+
+func UnserializeAttempt(value []byte) (*Attempt, error) {
+	if !bytes.HasPrefix(value, []byte("ATTP")) {
+		return nil, ErrAttemptCorrupt
+	}
+	startedAt, n := readUint64(value, 4)
+	duration, n := readUint64(value, n)
+	status, n := readUint32(value, n)
+	msg, n := readString(value, n)
+	if n < 0 {
+		return nil, ErrAttemptCorrupt
+	}
+	return &Attempt{
+		StartedAt: time.Unix(0, int64(startedAt)),
+		Duration:  int64(duration),
+		Status:    int32(status),
+		Error:     msg,
+		Response:  string(value[n:]),
+	}, nil
+}
+
+func NewTaskHistory(ID string, db store.Store, prefix, suffix []byte) *taskHistory {
+	return &taskHistory{
+		ID:     ID,
+		db:     db,
+		prefix: prefix,
+		suffix: suffix,
+	}
+}
+
+// taskHistory stores delivery attempts per task.
+// Key format: [line id] \x00 [key type] \x00 [task id] \x00 [started at]
+type taskHistory struct {
+	ID     string
+	db     store.Store
+	prefix []byte
+	suffix []byte
+}
+
+func (h *taskHistory) taskPrefix(taskID string) []byte {
+	k := make([]byte, 0, len(h.prefix)+len(taskID)+len(config.Prefix))
+	k = append(k, h.prefix...)
+	return append(k, []byte(taskID+config.Prefix)...)
+}
+
+func (h *taskHistory) Add(taskID string, attempt *Attempt) error {
+	k := append(h.taskPrefix(taskID), []byte(fmt.Sprintf("%020d", attempt.StartedAt.UnixNano()))...)
+	err := h.db.Put(k, attempt.Serialize())
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/history/task/%s - adding failed", h.ID, taskID), err)
+		return err
+	}
+	return nil
+}
+
+// Get returns all recorded attempts for a task, oldest first. Corrupt
+// attempts are skipped.
+func (h *taskHistory) Get(taskID string) ([]Attempt, error) {
+	prefix := h.taskPrefix(taskID)
+	res := []Attempt{}
+	iter := h.db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(prefix); iter.Valid(); iter.Next() {
+		if !bytes.HasPrefix(iter.Key(), prefix) {
+			break
+		}
+		attempt, err := UnserializeAttempt(iter.Value())
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/history/task/%s - skipping attempt %q", h.ID, taskID, iter.Key()[len(prefix):]), err)
+			continue
+		}
+		res = append(res, *attempt)
+	}
+	return res, nil
+}
+
+// Prune deletes all attempts started before the given time, along with
+// corrupt attempts.
+func (h *taskHistory) Prune(before time.Time) (int, error) {
+	keys := [][]byte{}
+	iter := h.db.NewIterator(nil)
+	for iter.Seek(h.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), h.suffix) > 0 {
+			break
+		}
+		attempt, err := UnserializeAttempt(iter.Value())
+		if err != nil || attempt.StartedAt.Before(before) {
+			keys = append(keys, append([]byte{}, iter.Key()...))
+		}
+	}
+	iter.Close()
+
+	for i, k := range keys {
+		err := h.db.Delete(k)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(keys), nil
+}
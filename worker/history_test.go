@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnserializeAttempt(t *testing.T) {
+	tests := []*Attempt{
+		{StartedAt: time.Unix(1400000000, 123), Duration: 250, Status: 200, Response: "ok"},
+		{StartedAt: time.Unix(1400000000, 0), Duration: 5000, Status: 503, Error: "unavailable"},
+		{StartedAt: time.Unix(1400000000, 0), Status: -1, Error: "timed out after 5s"},
+	}
+	for _, a := range tests {
+		value := a.Serialize()
+		got, err := UnserializeAttempt(value)
+		if err != nil || !reflect.DeepEqual(got, a) {
+			t.Errorf("Expected %+v, got %+v, %v", a, got, err)
+		}
+		// Every cut short record, the response is whatever follows the error.
+		for n := 0; n < len(value)-len(a.Response); n++ {
+			got, err := UnserializeAttempt(value[:n])
+			if err != ErrAttemptCorrupt {
+				t.Errorf("Expected ErrAttemptCorrupt for %q, got %+v, %v", value[:n], got, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/borgenk/qdo/config"
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+var (
+	ErrHostLimitNotFound    = errors.New("Host limit error: limit not found")
+	ErrHostLimitInvalidHost = errors.New("Host limit error: invalid host, give a host with optional port and path prefix")
+	ErrHostLimitInvalid     = errors.New("Host limit error: give max concurrent and/or max rate of zero or more, not both zero")
+	ErrHostLimitCorrupt     = errors.New("Host limit error: stored limit is corrupt")
+)
+
+// HostLimit caps the requests sent to a host by all queues together. Host is
+// a host name with an optional port and path prefix, i.e. api.internal or
+// api.internal:8080/v1. Without a port any port of the host matches. A task
+// is held to the most specific limit matching its target only.
+type HostLimit struct {
+	Host          string `json:"host"`
+	MaxConcurrent int32  `json:"max_concurrent"` // Requests in flight at once. Set 0 for no limit.
+	MaxRate       int32  `json:"max_rate"`       // Requests started per second. Set 0 for no limit.
+}
+
+// HostLimitState is a snapshot of a host limit and its current usage.
+type HostLimitState struct {
+	HostLimit
+	InFlight  int32          `json:"in_flight"` // Requests running now.
+	Waiting   int32          `json:"waiting"`   // Tasks waiting on the limit.
+	RateLimit RateLimitState `json:"rate_limit"`
+}
+
+// Validate checks the host and limits and normalizes the host.
+func (l *HostLimit) Validate() error {
+	l.Host = strings.ToLower(strings.TrimSpace(l.Host))
+	host, _ := splitHostLimit(l.Host)
+	if host == "" || strings.Contains(l.Host, "://") || strings.ContainsAny(l.Host, " ?#") {
+		return ErrHostLimitInvalidHost
+	}
+	if l.MaxConcurrent < 0 || l.MaxRate < 0 || (l.MaxConcurrent == 0 && l.MaxRate == 0) {
+		return ErrHostLimitInvalid
+	}
+	return nil
+}
+
+// splitHostLimit splits a limit's host into host and path prefix.
+func splitHostLimit(host string) (string, string) {
+	if i := strings.Index(host, "/"); i >= 0 {
+		return host[:i], host[i:]
+	}
+	return host, ""
+}
+
+// matches reports whether the limit applies to the target.
+func (l *HostLimit) matches(u *url.URL) bool {
+	host, prefix := splitHostLimit(l.Host)
+	target := strings.ToLower(u.Host)
+	if host != target && (strings.Contains(host, ":") || host != strings.ToLower(u.Hostname())) {
+		return false
+	}
+	return strings.HasPrefix(u.Path, prefix)
+}
+
+// HLIM(4)|maxConcurrent(4)|maxRate(4)|sizeOfHost(4)|host(x)
+func (l *HostLimit) Serialize() []byte {
+	out := []byte("HLIM")
+	out = appendUint32(out, uint32(l.MaxConcurrent))
+	out = appendUint32(out, uint32(l.MaxRate))
+	out = appendString(out, l.Host)
+	return out
+}
+
+func UnserializeHostLimit(value []byte) (*HostLimit, error) {
+	if !bytes.HasPrefix(value, []byte("HLIM")) {
+		return nil, ErrHostLimitCorrupt
+	}
+	maxConcurrent, n := readUint32(value, 4)
+	maxRate, n := readUint32(value, n)
+	host, n := readString(value, n)
+	if n < 0 {
+		return nil, ErrHostLimitCorrupt
+	}
+	return &HostLimit{Host: host, MaxConcurrent: int32(maxConcurrent), MaxRate: int32(maxRate)}, nil
+}
+
+// hostLimiter enforces a host limit for the tasks of all queues.
+type hostLimiter struct {
+	limit    HostLimit
+	slots    *slots
+	bucket   *tokenBucket
+	waiting  int32
+	inFlight int32
+}
+
+func newHostLimiter(l *HostLimit) *hostLimiter {
+	h := &hostLimiter{
+		slots:  newSlots(0),
+		bucket: &tokenBucket{},
+	}
+	h.set(l)
+	h.bucket.tokens = h.bucket.burst
+	return h
+}
+
+func (h *hostLimiter) set(l *HostLimit) {
+	h.limit = *l
+	if l.MaxConcurrent > 0 {
+		h.slots.Resize(l.MaxConcurrent)
+	} else {
+		h.slots.Resize(math.MaxInt32)
+	}
+	h.bucket.setRate(float64(l.MaxRate), 1)
+}
+
+// acquire blocks until a request may be sent under the limit.
+func (h *hostLimiter) acquire() {
+	atomic.AddInt32(&h.waiting, 1)
+	h.slots.Acquire()
+	for d := h.bucket.Take(); d > 0; d = h.bucket.Take() {
+		time.Sleep(d)
+	}
+	atomic.AddInt32(&h.waiting, -1)
+	atomic.AddInt32(&h.inFlight, 1)
+}
+
+func (h *hostLimiter) release() {
+	atomic.AddInt32(&h.inFlight, -1)
+	h.slots.Release()
+}
+
+func (h *hostLimiter) state() HostLimitState {
+	return HostLimitState{
+		HostLimit: h.limit,
+		InFlight:  atomic.LoadInt32(&h.inFlight),
+		Waiting:   atomic.LoadInt32(&h.waiting),
+		RateLimit: h.bucket.State(),
+	}
+}
+
+// Host limits apply to all queues, so they are stored apart from any queue.
+// Key format: [key type] \x00 [host]
+var (
+	hostLimitMu sync.Mutex
+	hostLimits  = map[string]*hostLimiter{}
+)
+
+func hostLimitKey(host string) []byte {
+	return []byte(config.HostLimitKey + config.Prefix + host)
+}
+
+// LoadHostLimits reads the stored host limits, called once on startup.
+func LoadHostLimits(db store.Store) error {
+	hostLimitMu.Lock()
+	defer hostLimitMu.Unlock()
+	start := []byte(config.HostLimitKey + config.Prefix)
+	end := []byte(config.HostLimitKey + config.Suffix)
+	iter := db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(start); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), end) > 0 {
+			break
+		}
+		l, err := UnserializeHostLimit(append([]byte{}, iter.Value()...))
+		if err != nil {
+			log.Error(fmt.Sprintf("host/%s - skipping limit", iter.Key()[len(start):]), err)
+			continue
+		}
+		hostLimits[l.Host] = newHostLimiter(l)
+	}
+	return nil
+}
+
+// SetHostLimit adds or changes a host limit. Requests in flight are not
+// interrupted when a limit is lowered.
+func SetHostLimit(db store.Store, l *HostLimit) error {
+	err := l.Validate()
+	if err != nil {
+		return err
+	}
+	hostLimitMu.Lock()
+	defer hostLimitMu.Unlock()
+	err = db.Put(hostLimitKey(l.Host), l.Serialize())
+	if err != nil {
+		log.Error(fmt.Sprintf("host/%s - storing limit failed", l.Host), err)
+		return err
+	}
+	if h, ok := hostLimits[l.Host]; ok {
+		h.set(l)
+	} else {
+		hostLimits[l.Host] = newHostLimiter(l)
+	}
+	log.Infof("host/%s - limited to %d concurrent, %d per second", l.Host, l.MaxConcurrent, l.MaxRate)
+	return nil
+}
+
+// DeleteHostLimit removes a host limit. Tasks waiting on it are let through.
+func DeleteHostLimit(db store.Store, host string) error {
+	hostLimitMu.Lock()
+	defer hostLimitMu.Unlock()
+	h, ok := hostLimits[host]
+	if !ok {
+		return ErrHostLimitNotFound
+	}
+	err := db.Delete(hostLimitKey(host))
+	if err != nil {
+		return err
+	}
+	delete(hostLimits, host)
+	h.set(&HostLimit{Host: host})
+	log.Infof("host/%s - limit removed", host)
+	return nil
+}
+
+// GetHostLimit returns a host limit and its usage.
+func GetHostLimit(host string) (*HostLimitState, error) {
+	hostLimitMu.Lock()
+	defer hostLimitMu.Unlock()
+	h, ok := hostLimits[host]
+	if !ok {
+		return nil, ErrHostLimitNotFound
+	}
+	res := h.state()
+	return &res, nil
+}
+
+// GetHostLimits returns all host limits and their usage, sorted by host.
+func GetHostLimits() []HostLimitState {
+	hostLimitMu.Lock()
+	defer hostLimitMu.Unlock()
+	res := make([]HostLimitState, 0, len(hostLimits))
+	for _, h := range hostLimits {
+		res = append(res, h.state())
+	}
+	sort.Sort(byHost(res))
+	return res
+}
+
+type byHost []HostLimitState
+
+func (s byHost) Len() int           { return len(s) }
+func (s byHost) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byHost) Less(i, j int) bool { return s[i].Host < s[j].Host }
+
+// hostLimiterFor returns the most specific limiter matching the target, nil
+// if the target is not limited.
+func hostLimiterFor(target string) *hostLimiter {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	hostLimitMu.Lock()
+	defer hostLimitMu.Unlock()
+	var res *hostLimiter
+	for _, h := range hostLimits {
+		if h.limit.matches(u) && (res == nil || len(h.limit.Host) > len(res.limit.Host)) {
+			res = h
+		}
+	}
+	return res
+}
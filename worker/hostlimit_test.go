@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestHostLimitValidate(t *testing.T) {
+	tests := []struct {
+		limit HostLimit
+		host  string
+		err   error
+	}{
+		{HostLimit{Host: " API.Internal ", MaxConcurrent: 2}, "api.internal", nil},
+		{HostLimit{Host: "api.internal:8080/v1", MaxRate: 5}, "api.internal:8080/v1", nil},
+		{HostLimit{Host: "", MaxRate: 5}, "", ErrHostLimitInvalidHost},
+		{HostLimit{Host: "/v1", MaxRate: 5}, "/v1", ErrHostLimitInvalidHost},
+		{HostLimit{Host: "http://api.internal", MaxRate: 5}, "http://api.internal", ErrHostLimitInvalidHost},
+		{HostLimit{Host: "api.internal/v1?x=1", MaxRate: 5}, "api.internal/v1?x=1", ErrHostLimitInvalidHost},
+		{HostLimit{Host: "api.internal"}, "api.internal", ErrHostLimitInvalid},
+		{HostLimit{Host: "api.internal", MaxConcurrent: -1, MaxRate: 5}, "api.internal", ErrHostLimitInvalid},
+		{HostLimit{Host: "api.internal", MaxConcurrent: 5, MaxRate: -1}, "api.internal", ErrHostLimitInvalid},
+	}
+	for _, test := range tests {
+		l := test.limit
+		err := l.Validate()
+		if err != test.err {
+			t.Errorf("Expected %v for %+v, got %v", test.err, test.limit, err)
+		}
+		if l.Host != test.host {
+			t.Errorf("Expected host %q for %+v, got %q", test.host, test.limit, l.Host)
+		}
+	}
+}
+
+func TestHostLimitMatches(t *testing.T) {
+	tests := []struct {
+		host   string
+		target string
+		match  bool
+	}{
+		{"api.internal", "http://api.internal/tasks", true},
+		{"api.internal", "http://API.internal:8080/tasks", true},
+		{"api.internal", "http://other.internal/tasks", false},
+		{"api.internal:8080", "http://api.internal:8080/tasks", true},
+		{"api.internal:8080", "http://api.internal:9090/tasks", false},
+		{"api.internal:8080", "http://api.internal/tasks", false},
+		{"api.internal/v1", "http://api.internal/v1/tasks", true},
+		{"api.internal/v1", "http://api.internal/v2/tasks", false},
+		{"api.internal:8080/v1", "http://api.internal:8080/v1", true},
+	}
+	for _, test := range tests {
+		u, err := url.Parse(test.target)
+		if err != nil {
+			t.Fatalf("Expected no error parsing %s, got %s", test.target, err)
+		}
+		l := &HostLimit{Host: test.host}
+		if got := l.matches(u); got != test.match {
+			t.Errorf("Expected %s matching %s to be %v, got %v", test.host, test.target, test.match, got)
+		}
+	}
+}
+
+func TestHostLimitSerialize(t *testing.T) {
+	l := &HostLimit{Host: "api.internal:8080/v1", MaxConcurrent: 3, MaxRate: 10}
+	value := l.Serialize()
+	got, err := UnserializeHostLimit(value)
+	if err != nil || !reflect.DeepEqual(got, l) {
+		t.Errorf("Expected %+v, got %+v, %v", l, got, err)
+	}
+
+	values := [][]byte{nil, []byte("JUNK")}
+	for n := 0; n < len(value); n++ {
+		values = append(values, value[:n])
+	}
+	for _, value := range values {
+		got, err := UnserializeHostLimit(value)
+		if err != ErrHostLimitCorrupt {
+			t.Errorf("Expected ErrHostLimitCorrupt for %q, got %+v, %v", value, got, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+// DefaultIdempotencyWindow is the number of seconds an idempotency key is
+// remembered when the queue does not configure it.
+const DefaultIdempotencyWindow int32 = 24 * 60 * 60
+
+// IDEM(4)|createdAt(8)|sizeOfID(4)|id(x)|task(x)
+func serializeIdempotencyEntry(task *Task, createdAt time.Time) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(createdAt.UnixNano()))
+
+	out := append([]byte("IDEM"), b...)
+	out = appendString(out, task.ID)
+	out = append(out, task.Serialize()...)
+	return out
+}
+
+func unserializeIdempotencyEntry(value []byte) (*Task, time.Time, error) {
+	if !bytes.HasPrefix(value, []byte("IDEM")) {
+		return nil, time.Time{}, ErrTaskCorrupt
+	}
+	createdAt, n := readUint64(value, 4)
+	id, n := readString(value, n)
+	if n < 0 {
+		return nil, time.Time{}, ErrTaskCorrupt
+	}
+	task, err := UnserializeTask(nil, value[n:len(value)])
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	task.ID = id
+	return task, time.Unix(0, int64(createdAt)), nil
+}
+
+func NewIdempotencyIndex(ID string, db store.Store, prefix, suffix []byte) *idempotencyIndex {
+	return &idempotencyIndex{
+		ID:     ID,
+		db:     db,
+		prefix: prefix,
+		suffix: suffix,
+	}
+}
+
+// idempotencyIndex remembers which task was created for an idempotency key.
+// Key format: [line id] \x00 [key type] \x00 [idempotency key]
+type idempotencyIndex struct {
+	ID     string
+	db     store.Store
+	prefix []byte
+	suffix []byte
+}
+
+func (x *idempotencyIndex) key(idempotencyKey string) []byte {
+	k := make([]byte, 0, len(x.prefix)+len(idempotencyKey))
+	k = append(k, x.prefix...)
+	return append(k, []byte(idempotencyKey)...)
+}
+
+// Get returns the task stored for the key if it was stored after the given
+// time, otherwise nil.
+func (x *idempotencyIndex) Get(idempotencyKey string, after time.Time) *Task {
+	k := x.key(idempotencyKey)
+	iter := x.db.NewIterator(nil)
+	defer iter.Close()
+	iter.Seek(k)
+	if !iter.Valid() || !bytes.Equal(iter.Key(), k) {
+		return nil
+	}
+	task, createdAt, err := unserializeIdempotencyEntry(iter.Value())
+	if err != nil || createdAt.Before(after) {
+		return nil
+	}
+	return task
+}
+
+func (x *idempotencyIndex) Add(idempotencyKey string, task *Task) error {
+	err := x.db.Put(x.key(idempotencyKey), serializeIdempotencyEntry(task, time.Now()))
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/idempotency/task/%s - adding failed", x.ID, task.ID), err)
+		return err
+	}
+	return nil
+}
+
+// stage adds the key when the batch b is written.
+func (x *idempotencyIndex) stage(b store.Batch, idempotencyKey string, task *Task) {
+	b.Put(x.key(idempotencyKey), serializeIdempotencyEntry(task, time.Now()))
+}
+
+// Prune deletes all keys stored before the given time.
+func (x *idempotencyIndex) Prune(before time.Time) (int, error) {
+	keys := [][]byte{}
+	iter := x.db.NewIterator(nil)
+	for iter.Seek(x.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), x.suffix) > 0 {
+			break
+		}
+		// Entries that can not be read are dropped too.
+		if _, createdAt, err := unserializeIdempotencyEntry(iter.Value()); err != nil || createdAt.Before(before) {
+			keys = append(keys, append([]byte{}, iter.Key()...))
+		}
+	}
+	iter.Close()
+
+	for i, k := range keys {
+		err := x.db.Delete(k)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(keys), nil
+}
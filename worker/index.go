@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/borgenk/qdo/config"
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+func NewTaskIndex(ID string, db store.Store, prefix, suffix []byte) *taskIndex {
+	return &taskIndex{
+		ID:     ID,
+		db:     db,
+		prefix: prefix,
+		suffix: suffix,
+	}
+}
+
+// taskIndex maps the id of a waiting or scheduled task to the key it is
+// stored under. Line keys are ordered by time, so without it finding a task
+// by id means scanning its lines.
+// Key format: [line id] \x00 [key type] \x00 [task id]
+type taskIndex struct {
+	ID     string
+	db     store.Store
+	prefix []byte
+	suffix []byte
+}
+
+func (x *taskIndex) key(taskID string) []byte {
+	k := make([]byte, 0, len(x.prefix)+len(taskID))
+	k = append(k, x.prefix...)
+	return append(k, []byte(taskID)...)
+}
+
+// taskKeyID returns the task id at the end of a line key.
+func taskKeyID(key []byte) string {
+	return string(key[bytes.LastIndex(key, []byte(config.Prefix))+1:])
+}
+
+// Get returns the key the task is stored under, or nil if not indexed.
+func (x *taskIndex) Get(taskID string) []byte {
+	k := x.key(taskID)
+	iter := x.db.NewIterator(nil)
+	defer iter.Close()
+	iter.Seek(k)
+	if !iter.Valid() || !bytes.Equal(iter.Key(), k) {
+		return nil
+	}
+	return append([]byte{}, iter.Value()...)
+}
+
+// Put points the id of the task at its current key.
+func (x *taskIndex) Put(taskID string, key []byte) error {
+	err := x.db.Put(x.key(taskID), key)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/index/task/%s - adding failed", x.ID, taskID), err)
+	}
+	return err
+}
+
+// stage points the id of the task at its key when the batch b is written.
+func (x *taskIndex) stage(b store.Batch, taskID string, key []byte) {
+	b.Put(x.key(taskID), key)
+}
+
+// Delete removes the id of the task stored under key. A task moved between
+// lines is added under its new key before the old one is deleted, so the id
+// is kept when it already points elsewhere.
+func (x *taskIndex) Delete(key []byte) error {
+	taskID := taskKeyID(key)
+	if !bytes.Equal(x.Get(taskID), key) {
+		return nil
+	}
+	err := x.db.Delete(x.key(taskID))
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/index/task/%s - deleting failed", x.ID, taskID), err)
+	}
+	return err
+}
+
+// Rebuild indexes every task in the given lines and drops ids of tasks no
+// longer stored. Needed once for tasks stored before the index existed.
+func (x *taskIndex) Rebuild(lines ...*queueLine) error {
+	stale := [][]byte{}
+	iter := x.db.NewIterator(nil)
+	for iter.Seek(x.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), x.suffix) > 0 {
+			break
+		}
+		found := false
+		for _, line := range lines {
+			if _, err := line.getKey(iter.Value()); err == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			stale = append(stale, append([]byte{}, iter.Key()...))
+		}
+	}
+	iter.Close()
+	for _, k := range stale {
+		err := x.db.Delete(k)
+		if err != nil {
+			return err
+		}
+	}
+
+	n := 0
+	for _, line := range lines {
+		keys := [][]byte{}
+		iter := x.db.NewIterator(nil)
+		for iter.Seek(line.prefix); iter.Valid(); iter.Next() {
+			if bytes.Compare(iter.Key(), line.suffix) > 0 {
+				break
+			}
+			if x.Get(taskKeyID(iter.Key())) == nil {
+				keys = append(keys, append([]byte{}, iter.Key()...))
+			}
+		}
+		iter.Close()
+		for _, k := range keys {
+			err := x.Put(taskKeyID(k), k)
+			if err != nil {
+				return err
+			}
+			n++
+		}
+	}
+	if n > 0 || len(stale) > 0 {
+		log.Infof("queue/%s/index - indexed %d task(s), dropped %d", x.ID, n, len(stale))
+	}
+	return nil
+}
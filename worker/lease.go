@@ -0,0 +1,241 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/borgenk/qdo/config"
+	"github.com/borgenk/qdo/log"
+)
+
+const (
+	ModePush = "push"
+	ModePull = "pull"
+)
+
+const (
+	DefaultLeaseVisibility = 30 * time.Second
+	MaxLeaseVisibility     = 12 * time.Hour
+	MaxLeaseCount          = 100
+)
+
+var (
+	ErrLeaseNotPull           = errors.New("Lease error: queue is not in pull mode")
+	ErrLeaseNotFound          = errors.New("Lease error: lease not found or expired")
+	ErrLeaseInvalidCount      = errors.New("Lease error: invalid count")
+	ErrLeaseInvalidVisibility = errors.New("Lease error: invalid visibility")
+)
+
+// LeasedTask is a task handed to a pull consumer. The task stays hidden in
+// the schedule line until the lease runs out, then it is waiting again unless
+// the consumer acknowledged it.
+type LeasedTask struct {
+	Task
+	LeaseID     string `json:"lease_id"`
+	LeasedUntil int64  `json:"leased_until"`
+}
+
+// leaseOrder builds the schedule line order of a leased task. It sorts by the
+// time the lease runs out and also holds the time the task was leased, which
+// makes it unique per lease.
+// Format: [leased until] . [leased at in nanoseconds]
+func leaseOrder(until int64, leasedAt time.Time) string {
+	return fmt.Sprintf("%d.%d", until, leasedAt.UnixNano())
+}
+
+// parseLeaseOrder returns the time a task was leased from its lease id.
+func parseLeaseOrder(leaseID string) (until int64, leasedAt time.Time, ok bool) {
+	i := strings.Index(leaseID, ".")
+	if i < 0 {
+		return 0, time.Time{}, false
+	}
+	until, err := strconv.ParseInt(leaseID[:i], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(leaseID[i+1:], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return until, time.Unix(0, nanos), true
+}
+
+// isLeaseKey reports whether a schedule line key belongs to a leased task.
+func (s *scheduleQueue) isLeaseKey(key []byte) bool {
+	order := key[len(s.prefix):]
+	if i := bytes.IndexByte(order, config.Prefix[0]); i >= 0 {
+		order = order[:i]
+	}
+	return bytes.IndexByte(order, '.') >= 0
+}
+
+// getLeased returns the task held under the given lease.
+func (s *scheduleQueue) getLeased(taskID, leaseID string) (*Task, error) {
+	task, err := s.getKey(append(append([]byte{}, s.prefix...), []byte(leaseID+config.Prefix+taskID)...))
+	if err != nil {
+		return nil, ErrLeaseNotFound
+	}
+	return task, nil
+}
+
+// Lease hands out up to count waiting tasks, highest priority first, and
+// hides them for the visibility period. Every lease counts as a try; tasks
+// that reached the queue's max tries are moved to the dead queue instead.
+func (q *QueueManager) Lease(count int, visibility time.Duration) ([]LeasedTask, error) {
+	c, _ := q.getConfig()
+	if c.Mode != ModePull {
+		return nil, ErrLeaseNotPull
+	}
+	if count < 1 || count > MaxLeaseCount {
+		return nil, ErrLeaseInvalidCount
+	}
+	if visibility <= 0 || visibility > MaxLeaseVisibility {
+		return nil, ErrLeaseInvalidVisibility
+	}
+
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+
+	res := []LeasedTask{}
+	if q.IsPaused() {
+		return res, nil
+	}
+	now := time.Now()
+	until := now.Add(visibility).Unix()
+	for priority := MaxTaskPriority; priority >= MinTaskPriority && len(res) < count; priority-- {
+		for _, task := range q.waitQueue.read(priority, nil, count-len(res)) {
+			k := task.Key
+			if task.Expired(now) {
+				q.expireTask(task)
+			} else if c.TaskMaxTries > 0 && task.Tries >= c.TaskMaxTries {
+				log.Infof("queue/%s/task/%s - max tries reached (%d)", q.ID, task.ID, task.Tries)
+				err := q.killTask(task, ErrTaskMaxTries)
+				if err != nil {
+					return res, err
+				}
+			} else {
+				task.Tries = task.Tries + 1
+				leaseID := leaseOrder(until, now)
+				err := q.scheduleQueue.add(task, leaseID)
+				if err != nil {
+					return res, err
+				}
+				res = append(res, LeasedTask{Task: *task, LeaseID: leaseID, LeasedUntil: until})
+			}
+			err := q.waitQueue.Delete(k)
+			if err != nil {
+				return res, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// Ack completes a leased task. If the task is a pipeline stage the result is
+// the payload of the next stage.
+func (q *QueueManager) Ack(taskID, leaseID, result string) error {
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+
+	task, leasedAt, err := q.findLeased(taskID, leaseID)
+	if err != nil {
+		return err
+	}
+	// The batch member is settled before the task leaves the queue.
+	if task.BatchID != "" {
+		q.batchTaskDone(task, true)
+	}
+	err = q.scheduleQueue.Delete(task.Key)
+	if err != nil {
+		return err
+	}
+	log.Infof("queue/%s/task/%s - acknowledged", q.ID, task.ID)
+	q.history.Add(task.ID, &Attempt{
+		StartedAt: leasedAt,
+		Duration:  int64(time.Since(leasedAt) / time.Millisecond),
+	})
+	q.stats.TotalProcessedOK.Add(1)
+	q.statsProcessingQuantile.Insert(float64(time.Since(leasedAt) / time.Millisecond))
+	if task.PipelineID != "" {
+		q.advancePipeline(task, []byte(result), "")
+	}
+	return nil
+}
+
+// Nack gives a leased task back after a failure. It is retried after the
+// delay of the queue's retry policy.
+func (q *QueueManager) Nack(taskID, leaseID, reason string) error {
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+
+	task, leasedAt, err := q.takeLeased(taskID, leaseID)
+	if err != nil {
+		return err
+	}
+	log.Infof("queue/%s/task/%s - not acknowledged: %s", q.ID, task.ID, reason)
+	q.history.Add(task.ID, &Attempt{
+		StartedAt: leasedAt,
+		Duration:  int64(time.Since(leasedAt) / time.Millisecond),
+		Error:     reason,
+	})
+	q.stats.TotalProcessedError.Add(1)
+	c, _ := q.getConfig()
+	task.Delay = c.Retry.Delay(task.Tries)
+	q.retryTask(task)
+	return nil
+}
+
+// ExtendLease hides a leased task for another visibility period from now.
+// The task gets a new lease id, the old one is no longer valid.
+func (q *QueueManager) ExtendLease(taskID, leaseID string, visibility time.Duration) (*LeasedTask, error) {
+	if visibility <= 0 || visibility > MaxLeaseVisibility {
+		return nil, ErrLeaseInvalidVisibility
+	}
+
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+
+	task, leasedAt, err := q.takeLeased(taskID, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	until := time.Now().Add(visibility).Unix()
+	newLeaseID := leaseOrder(until, leasedAt)
+	err = q.scheduleQueue.add(task, newLeaseID)
+	if err != nil {
+		return nil, err
+	}
+	return &LeasedTask{Task: *task, LeaseID: newLeaseID, LeasedUntil: until}, nil
+}
+
+// findLeased returns a task under a lease that has not expired. The caller
+// holds leaseMu.
+func (q *QueueManager) findLeased(taskID, leaseID string) (*Task, time.Time, error) {
+	until, leasedAt, ok := parseLeaseOrder(leaseID)
+	if !ok || until < time.Now().Unix() {
+		return nil, leasedAt, ErrLeaseNotFound
+	}
+	task, err := q.scheduleQueue.getLeased(taskID, leaseID)
+	if err != nil {
+		return nil, leasedAt, err
+	}
+	return task, leasedAt, nil
+}
+
+// takeLeased removes a leased task from the schedule line. The caller holds
+// leaseMu.
+func (q *QueueManager) takeLeased(taskID, leaseID string) (*Task, time.Time, error) {
+	task, leasedAt, err := q.findLeased(taskID, leaseID)
+	if err != nil {
+		return nil, leasedAt, err
+	}
+	err = q.scheduleQueue.Delete(task.Key)
+	if err != nil {
+		return nil, leasedAt, err
+	}
+	return task, leasedAt, nil
+}
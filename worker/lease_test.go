@@ -0,0 +1,22 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeaseOrder(t *testing.T) {
+	leasedAt := time.Unix(1400000000, 123456789)
+	until, got, ok := parseLeaseOrder(leaseOrder(1400000030, leasedAt))
+	if !ok || until != 1400000030 || !got.Equal(leasedAt) {
+		t.Errorf("Expected 1400000030 and %s, got %d and %s, %v", leasedAt, until, got, ok)
+	}
+}
+
+func TestParseLeaseOrderInvalid(t *testing.T) {
+	for _, id := range []string{"", "1400000030", "abc.123", "1400000030.abc", "1400000030.", ".123"} {
+		if _, _, ok := parseLeaseOrder(id); ok {
+			t.Errorf("Expected lease id %q to be invalid", id)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/borgenk/qdo/config"
+	"github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/store"
+)
+
+const (
+	PipelineStateRunning = "running"
+	PipelineStateDone    = "done"
+	PipelineStateFailed  = "failed"
+)
+
+const (
+	MaxPipelineStages = 32
+	// MaxStagePayload is the maximum number of response body bytes passed on
+	// to the next stage.
+	MaxStagePayload = 1 << 20
+)
+
+var (
+	ErrPipelineNotFound      = errors.New("Pipeline error: pipeline not found")
+	ErrPipelineInvalidStages = errors.New("Pipeline error: invalid number of stages")
+	ErrPipelineInvalidQueue  = errors.New("Pipeline error: stage queue not found")
+	ErrPipelineStageExpired  = errors.New("Pipeline error: stage expired")
+	ErrPipelineCorrupt       = errors.New("Pipeline error: stored pipeline is corrupt")
+)
+
+// queueLookup finds the queue of a stage, set by the controller owning the
+// queues.
+var queueLookup func(queueID string) (*QueueManager, error)
+
+// SetQueueLookup sets the function pipelines use to find the queue a stage
+// runs in.
+func SetQueueLookup(fn func(queueID string) (*QueueManager, error)) {
+	queueLookup = fn
+}
+
+// PipelineStage is one step of a pipeline. Every stage but the first is
+// posted the response body of the stage before it.
+type PipelineStage struct {
+	Queue  string `json:"queue"` // Queue the stage runs in.
+	Target string `json:"target"`
+	TaskID string `json:"task_id"` // Task running the stage, empty until started.
+}
+
+// Pipeline tracks the progress of a chain of tasks.
+type Pipeline struct {
+	ID        string          `json:"id"`
+	State     string          `json:"state"`   // One of running, done or failed.
+	Current   int32           `json:"current"` // Index of the stage running or last run.
+	Error     string          `json:"error"`   // Why the pipeline failed.
+	CreatedAt int64           `json:"created_at"`
+	UpdatedAt int64           `json:"updated_at"`
+	Stages    []PipelineStage `json:"stages"`
+}
+
+// PIPE(4)|current(4)|createdAt(8)|updatedAt(8)|sizeOfID(4)|id(x)|
+// sizeOfState(4)|state(x)|sizeOfError(4)|error(x)|numStages(4)|
+// [sizeOfQueue(4)|queue(x)|sizeOfTarget(4)|target(x)|sizeOfTaskID(4)|taskID(x)]...
+func (p *Pipeline) Serialize() []byte {
+	out := []byte("PIPE")
+	out = appendUint32(out, uint32(p.Current))
+	out = appendUint64(out, uint64(p.CreatedAt))
+	out = appendUint64(out, uint64(p.UpdatedAt))
+	out = appendString(out, p.ID)
+	out = appendString(out, p.State)
+	out = appendString(out, p.Error)
+	out = appendUint32(out, uint32(len(p.Stages)))
+	for _, s := range p.Stages {
+		out = appendString(out, s.Queue)
+		out = appendString(out, s.Target)
+		out = appendString(out, s.TaskID)
+	}
+	return out
+}
+
+func UnserializePipeline(value []byte) (*Pipeline, error) {
+	if !bytes.HasPrefix(value, []byte("PIPE")) {
+		return nil, ErrPipelineCorrupt
+	}
+	p := &Pipeline{}
+	current, n := readUint32(value, 4)
+	createdAt, n := readUint64(value, n)
+	updatedAt, n := readUint64(value, n)
+	p.ID, n = readString(value, n)
+	p.State, n = readString(value, n)
+	p.Error, n = readString(value, n)
+	numStages, n := readUint32(value, n)
+	// Every stage takes at least the sizes of its three strings.
+	if n < 0 || uint64(numStages) > uint64(len(value)-n)/12 {
+		return nil, ErrPipelineCorrupt
+	}
+	p.Current = int32(current)
+	p.CreatedAt = int64(createdAt)
+	p.UpdatedAt = int64(updatedAt)
+	p.Stages = make([]PipelineStage, numStages)
+	for i := range p.Stages {
+		p.Stages[i].Queue, n = readString(value, n)
+		p.Stages[i].Target, n = readString(value, n)
+		p.Stages[i].TaskID, n = readString(value, n)
+	}
+	if n < 0 {
+		return nil, ErrPipelineCorrupt
+	}
+	return p, nil
+}
+
+// Pipelines span queues, so they are stored apart from any queue and changes
+// are serialized over all queues.
+// Key format: [key type] \x00 [pipeline id]
+var pipelineMu sync.Mutex
+
+func pipelineKey(pipelineID string) []byte {
+	return []byte(config.PipelineKey + config.Prefix + pipelineID)
+}
+
+// GetPipeline returns the stored pipeline with the given id.
+func GetPipeline(db store.Store, pipelineID string) (*Pipeline, error) {
+	k := pipelineKey(pipelineID)
+	iter := db.NewIterator(nil)
+	defer iter.Close()
+	iter.Seek(k)
+	if !iter.Valid() || !bytes.Equal(iter.Key(), k) {
+		return nil, ErrPipelineNotFound
+	}
+	return UnserializePipeline(append([]byte{}, iter.Value()...))
+}
+
+func putPipeline(db store.Store, p *Pipeline) error {
+	p.UpdatedAt = time.Now().Unix()
+	err := db.Put(pipelineKey(p.ID), p.Serialize())
+	if err != nil {
+		log.Error(fmt.Sprintf("pipeline/%s - storing failed", p.ID), err)
+		return err
+	}
+	return nil
+}
+
+// ParsePipelineStage reads a stage given as "target" or "queue_id target". A
+// stage without a queue runs in the queue of the stage before it.
+func ParsePipelineStage(value string) PipelineStage {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexAny(value, " \t"); i >= 0 {
+		return PipelineStage{
+			Queue:  value[:i],
+			Target: strings.TrimSpace(value[i+1:]),
+		}
+	}
+	return PipelineStage{Target: value}
+}
+
+// EnqueuePipeline adds the task as the first stage of a new pipeline. The
+// stages given run after it, in order. The pipeline gets the id of the task.
+func (q *QueueManager) EnqueuePipeline(task *Task, stages []PipelineStage, scheduled int64) (*Task, error) {
+	if len(stages) < 1 || len(stages)+1 > MaxPipelineStages {
+		return nil, ErrPipelineInvalidStages
+	}
+	all := make([]PipelineStage, 0, len(stages)+1)
+	all = append(all, PipelineStage{Queue: q.ID, Target: task.Target})
+	for _, s := range stages {
+		if s.Queue == "" {
+			s.Queue = all[len(all)-1].Queue
+		}
+		sq, err := q.lookupQueue(s.Queue)
+		if err != nil {
+			return nil, err
+		}
+		err = sq.checkTarget(&Task{Target: s.Target})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, s)
+	}
+	if task.ID == "" {
+		task.ID = <-q.newTaskID
+	}
+	task.PipelineID = task.ID
+	task.Stage = 0
+
+	// Hold the lock until the pipeline is stored so the first stage can not
+	// complete before.
+	pipelineMu.Lock()
+	defer pipelineMu.Unlock()
+
+	res, err := q.Enqueue(task, scheduled)
+	if err != nil {
+		return nil, err
+	}
+	all[0].TaskID = res.ID
+	p := &Pipeline{
+		ID:        res.ID,
+		State:     PipelineStateRunning,
+		CreatedAt: time.Now().Unix(),
+		Stages:    all,
+	}
+	err = putPipeline(q.db, p)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// lookupQueue finds the queue a stage runs in.
+func (q *QueueManager) lookupQueue(queueID string) (*QueueManager, error) {
+	if queueID == q.ID {
+		return q, nil
+	}
+	if queueLookup == nil {
+		return nil, ErrPipelineInvalidQueue
+	}
+	res, err := queueLookup(queueID)
+	if err != nil {
+		return nil, ErrPipelineInvalidQueue
+	}
+	return res, nil
+}
+
+// advancePipeline starts the stage after the one the task ran, with the
+// response of the task as payload, or completes the pipeline after its last
+// stage.
+func (q *QueueManager) advancePipeline(task *Task, payload []byte, contentType string) {
+	pipelineMu.Lock()
+	defer pipelineMu.Unlock()
+
+	p, ok := q.pipelineAt(task)
+	if !ok {
+		return
+	}
+	next := task.Stage + 1
+	if int(next) >= len(p.Stages) {
+		log.Infof("pipeline/%s - done", p.ID)
+		p.State = PipelineStateDone
+		putPipeline(q.db, p)
+		return
+	}
+
+	stage := p.Stages[next]
+	nq, err := q.lookupQueue(stage.Queue)
+	if err != nil {
+		q.failPipelineLocked(p, err)
+		return
+	}
+	nextTask := &Task{
+		Target:      stage.Target,
+		ContentType: contentType,
+		Payload:     string(payload),
+		Priority:    task.Priority,
+		PipelineID:  p.ID,
+		Stage:       next,
+	}
+	res, err := nq.Enqueue(nextTask, 0)
+	if err != nil {
+		q.failPipelineLocked(p, err)
+		return
+	}
+	log.Infof("pipeline/%s - stage %d started as queue/%s/task/%s", p.ID, next, stage.Queue, res.ID)
+	p.Current = next
+	p.Stages[next].TaskID = res.ID
+	putPipeline(q.db, p)
+}
+
+// failPipeline marks the pipeline of the task as failed.
+func (q *QueueManager) failPipeline(task *Task, reason error) {
+	pipelineMu.Lock()
+	defer pipelineMu.Unlock()
+
+	p, ok := q.pipelineAt(task)
+	if !ok {
+		return
+	}
+	q.failPipelineLocked(p, reason)
+}
+
+// resumePipeline marks the failed pipeline of a requeued task as running
+// again.
+func (q *QueueManager) resumePipeline(task *Task) {
+	pipelineMu.Lock()
+	defer pipelineMu.Unlock()
+
+	p, err := GetPipeline(q.db, task.PipelineID)
+	if err != nil || p.State != PipelineStateFailed || p.Current != task.Stage {
+		return
+	}
+	log.Infof("pipeline/%s - resuming at stage %d", p.ID, p.Current)
+	p.State = PipelineStateRunning
+	p.Error = ""
+	putPipeline(q.db, p)
+}
+
+func (q *QueueManager) failPipelineLocked(p *Pipeline, reason error) {
+	log.Infof("pipeline/%s - failed at stage %d: %s", p.ID, p.Current, reason)
+	p.State = PipelineStateFailed
+	p.Error = reason.Error()
+	putPipeline(q.db, p)
+}
+
+// pipelineAt returns the running pipeline of the task if the task is its
+// current stage. The caller holds pipelineMu.
+func (q *QueueManager) pipelineAt(task *Task) (*Pipeline, bool) {
+	p, err := GetPipeline(q.db, task.PipelineID)
+	if err != nil {
+		log.Error(fmt.Sprintf("pipeline/%s - reading failed", task.PipelineID), err)
+		return nil, false
+	}
+	if p.State != PipelineStateRunning || p.Current != task.Stage {
+		// A stage delivered twice, the pipeline has moved on already.
+		return nil, false
+	}
+	return p, true
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnserializePipeline(t *testing.T) {
+	p := &Pipeline{
+		ID:        "pipe",
+		State:     PipelineStateRunning,
+		Current:   1,
+		CreatedAt: 1400000000,
+		UpdatedAt: 1400000060,
+		Stages: []PipelineStage{
+			{Queue: "fetch", Target: "http://127.0.0.1/fetch", TaskID: "a"},
+			{Queue: "resize", Target: "exec://resize", TaskID: "b"},
+		},
+	}
+	value := p.Serialize()
+	got, err := UnserializePipeline(value)
+	if err != nil || !reflect.DeepEqual(got, p) {
+		t.Errorf("Expected %+v, got %+v, %v", p, got, err)
+	}
+
+	// Every cut short record and a stage count beyond the record.
+	values := [][]byte{nil, []byte("JUNK")}
+	for n := 0; n < len(value); n++ {
+		values = append(values, value[:n])
+	}
+	huge := (&Pipeline{ID: "pipe"}).Serialize()
+	values = append(values, append(huge[:len(huge)-4], 0xff, 0xff, 0xff, 0x7f))
+	for _, value := range values {
+		got, err := UnserializePipeline(value)
+		if err != ErrPipelineCorrupt {
+			t.Errorf("Expected ErrPipelineCorrupt for %q, got %+v, %v", value, got, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package worker
 import (
 	"bytes"
 	"fmt"
+	"sync"
 
 	"github.com/borgenk/qdo/config"
 	"github.com/borgenk/qdo/log"
@@ -13,16 +14,34 @@ type queueLine struct {
 	ID           string
 	Type         string
 	config       *Config
+	configMu     sync.RWMutex
 	db           store.Store
 	notifySignal chan systemSignal
+	paused       func() bool // Whether the queue is paused, looked up on pause and resume signals.
 	prefix       []byte
 	suffix       []byte
 	total        *AtomicInt
+	bytes        *AtomicInt // Payload bytes of the tasks in the line, nil if not counted.
+	index        *taskIndex // Nil for lines whose tasks are not looked up by id.
+}
+
+func (q *queueLine) getConfig() *Config {
+	q.configMu.RLock()
+	defer q.configMu.RUnlock()
+	return q.config
+}
+
+func (q *queueLine) setConfig(config *Config) {
+	q.configMu.Lock()
+	q.config = config
+	q.configMu.Unlock()
 }
 
 // Key format: [line id] \x00 [key type] \x00 [order] \x00 [task id]
 func (q *queueLine) key(task *Task, order string) []byte {
-	return append(q.prefix, []byte(fmt.Sprintf("%s%s%s", order, config.Prefix, task.ID))...)
+	k := make([]byte, len(q.prefix), len(q.prefix)+len(order)+len(config.Prefix)+len(task.ID))
+	copy(k, q.prefix)
+	return append(k, []byte(fmt.Sprintf("%s%s%s", order, config.Prefix, task.ID))...)
 }
 
 func (q *queueLine) add(task *Task, order string) error {
@@ -34,12 +53,161 @@ func (q *queueLine) add(task *Task, order string) error {
 		log.Error(fmt.Sprintf("queue/%s/%s/task/%s - adding failed", q.ID, q.Type, task.ID), err)
 		return err
 	}
+	q.added(task)
+	if q.index != nil {
+		return q.index.Put(task.ID, task.Key)
+	}
+	return nil
+}
+
+// stage puts the task in the batch b, along with its id in the index. The
+// line counts are left to added, once b is written.
+func (q *queueLine) stage(b store.Batch, task *Task, order string) {
+	log.Infof("queue/%s/%s/task/%s - adding", q.ID, q.Type, task.ID)
+
+	task.Key = q.key(task, order)
+	b.Put(task.Key, task.Serialize())
+	if q.index != nil {
+		q.index.stage(b, task.ID, task.Key)
+	}
+}
+
+// added counts a task put in the line.
+func (q *queueLine) added(task *Task) {
 	q.total.Add(1)
+	if q.bytes != nil {
+		q.bytes.Add(int64(len(task.Payload)))
+	}
+}
+
+// update stores a changed task in place of the old one, under the same key.
+func (q *queueLine) update(task *Task) error {
+	log.Infof("queue/%s/%s/task/%s - updating", q.ID, q.Type, task.ID)
+
+	old, err := q.getKey(task.Key)
+	if err != nil {
+		return err
+	}
+	err = q.db.Put(task.Key, task.Serialize())
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/%s/task/%s - updating failed", q.ID, q.Type, task.ID), err)
+		return err
+	}
+	if q.bytes != nil {
+		q.bytes.Add(int64(len(task.Payload) - len(old.Payload)))
+	}
 	return nil
 }
 
-func (q *queueLine) Get() {
+// recount sets the number of tasks and payload bytes in the line from what
+// is stored, as counts are not kept across restarts.
+func (q *queueLine) recount() {
+	var n, size int64
+	iter := q.db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(q.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), q.suffix) > 0 {
+			break
+		}
+		n++
+		if q.bytes != nil {
+			size += int64(len(taskPayload(iter.Value())))
+		}
+	}
+	q.total.Set(n)
+	if q.bytes != nil {
+		q.bytes.Set(size)
+	}
+}
+
+// taskPayload returns the payload of a stored task, empty if it can not be
+// read. Payload counts of the line stay in step as long as the same value
+// gives the same size.
+func taskPayload(value []byte) string {
+	task, err := UnserializeTask(nil, value)
+	if err != nil {
+		return ""
+	}
+	return task.Payload
+}
 
+// checkSignal handles a pending system signal without blocking. It returns
+// true if the line must stop.
+func (q *queueLine) checkSignal() bool {
+	select {
+	case sig := <-q.notifySignal:
+		return q.handleSignal(sig)
+	default:
+		return false
+	}
+}
+
+// handleSignal acts on a system signal and returns true if the line must
+// stop. Pause and resume signals may be dropped when signals are pending, so
+// on either the line checks whether the queue is paused and if so blocks
+// until it is resumed or stopped.
+func (q *queueLine) handleSignal(sig systemSignal) bool {
+	if sig == stop {
+		log.Infof("queue/%s/%s - stopping", q.ID, q.Type)
+		return true
+	}
+	if q.paused == nil || !q.paused() {
+		return false
+	}
+	log.Infof("queue/%s/%s - pausing", q.ID, q.Type)
+	for q.paused() {
+		if <-q.notifySignal == stop {
+			log.Infof("queue/%s/%s - stopping", q.ID, q.Type)
+			return true
+		}
+	}
+	log.Infof("queue/%s/%s - resuming", q.ID, q.Type)
+	return false
+}
+
+// Get returns the task with the given id, or ErrTaskNotFound. Keys are
+// ordered by time, not id, so lines without an index are scanned whole.
+func (q *queueLine) Get(taskID string) (*Task, error) {
+	if q.index != nil {
+		k := q.index.Get(taskID)
+		if k == nil || !bytes.HasPrefix(k, q.prefix) {
+			return nil, ErrTaskNotFound
+		}
+		return q.getKey(k)
+	}
+	suffix := []byte(config.Prefix + taskID)
+	iter := q.db.NewIterator(nil)
+	defer iter.Close()
+	for iter.Seek(q.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), q.suffix) > 0 {
+			break
+		}
+		if bytes.HasSuffix(iter.Key(), suffix) {
+			k := append([]byte{}, iter.Key()...)
+			return UnserializeTask(k, iter.Value())
+		}
+	}
+	return nil, ErrTaskNotFound
+}
+
+// getKey returns the task stored under the given key.
+func (q *queueLine) getKey(k []byte) (*Task, error) {
+	v := q.getValue(k)
+	if v == nil {
+		return nil, ErrTaskNotFound
+	}
+	return UnserializeTask(k, v)
+}
+
+// getValue returns the value stored under the given key, nil if none.
+func (q *queueLine) getValue(k []byte) []byte {
+	iter := q.db.NewIterator(nil)
+	defer iter.Close()
+	iter.Seek(k)
+	if !iter.Valid() || !bytes.Equal(iter.Key(), k) {
+		return nil
+	}
+	return append([]byte{}, iter.Value()...)
 }
 
 func (q *queueLine) GetAll() (*[]Task, error) {
@@ -54,7 +222,11 @@ func (q *queueLine) GetAll() (*[]Task, error) {
 			break
 		}
 
-		task := UnserializeTask(iter.Key(), iter.Value())
+		task, err := UnserializeTask(append([]byte{}, iter.Key()...), iter.Value())
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/%s - skipping task %q", q.ID, q.Type, iter.Key()), err)
+			continue
+		}
 		result = append(result, *task)
 
 		i++
@@ -65,15 +237,27 @@ func (q *queueLine) GetAll() (*[]Task, error) {
 	return &result, nil
 }
 
+// Delete removes the task stored under key. Deleting a task that is already
+// gone does nothing.
 func (q *queueLine) Delete(key []byte) error {
 	log.Debugf("queue/%s/%s/task/x - deleting", q.ID, q.Type)
 
+	v := q.getValue(key)
+	if v == nil {
+		return nil
+	}
 	err := q.db.Delete(key)
 	if err != nil {
 		log.Error(fmt.Sprintf("queue/%s/%s/task/x - deleting", q.ID, q.Type), err)
 		return err
 	}
 	q.total.Add(-1)
+	if q.bytes != nil {
+		q.bytes.Add(-int64(len(taskPayload(v))))
+	}
+	if q.index != nil {
+		return q.index.Delete(key)
+	}
 	return nil
 }
 
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket limits how often tasks are dispatched. Tokens are added at rate
+// per second up to burst, and every dispatch takes one.
+type tokenBucket struct {
+	mu        sync.Mutex
+	rate      float64 // Tokens added per second. Set 0 for no limit.
+	burst     float64 // Maximum number of tokens saved up.
+	tokens    float64
+	last      time.Time
+	throttled bool
+}
+
+func newTokenBucket(config *Config) *tokenBucket {
+	b := &tokenBucket{}
+	b.setConfig(config)
+	b.tokens = b.burst
+	return b
+}
+
+// setConfig changes rate and burst to those of the queue configuration.
+func (b *tokenBucket) setConfig(config *Config) {
+	b.setRate(config.Rate(), float64(config.RateBurst))
+}
+
+// setRate changes rate and burst. Saved tokens are kept up to the new burst.
+func (b *tokenBucket) setRate(rate, burst float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	b.rate = rate
+	b.burst = burst
+	if b.burst < 1 {
+		b.burst = 1
+	}
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// Take takes a token if one is available and returns zero. Otherwise it
+// returns how long until the next token is added and the caller should try
+// again after that.
+func (b *tokenBucket) Take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate <= 0 {
+		b.throttled = false
+		return 0
+	}
+	b.refill(time.Now())
+	if b.tokens >= 1 {
+		b.tokens--
+		b.throttled = false
+		return 0
+	}
+	b.throttled = true
+	d := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	if d < time.Millisecond {
+		d = time.Millisecond
+	}
+	return d
+}
+
+// RateLimitState is a snapshot of a queue's rate limiter.
+type RateLimitState struct {
+	Rate      float64 `json:"rate"`      // Tokens added per second, 0 for no limit.
+	Burst     float64 `json:"burst"`     // Maximum number of tokens saved up.
+	Tokens    float64 `json:"tokens"`    // Tokens available right now.
+	Throttled bool    `json:"throttled"` // Dispatch is waiting on a token.
+}
+
+func (b *tokenBucket) State() RateLimitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	return RateLimitState{
+		Rate:      b.rate,
+		Burst:     b.burst,
+		Tokens:    b.tokens,
+		Throttled: b.throttled,
+	}
+}
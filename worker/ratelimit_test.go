@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketTake(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    float64
+		burst   float64
+		tokens  float64 // Tokens left before the take.
+		elapsed time.Duration
+		want    time.Duration // Wait returned by Take, 0 when a token is taken.
+	}{
+		{"no limit", 0, 1, 0, 0, 0},
+		{"token saved", 2, 1, 1, 0, 0},
+		{"empty", 2, 1, 0, 0, 500 * time.Millisecond},
+		{"half refilled", 2, 1, 0, 250 * time.Millisecond, 250 * time.Millisecond},
+		{"refilled", 2, 1, 0, 500 * time.Millisecond, 0},
+		{"slow rate", 0.1, 1, 0.5, 0, 5 * time.Second},
+		{"burst", 1, 5, 4.5, 0, 0},
+		{"refill capped at burst", 10, 3, 0, time.Hour, 0},
+	}
+	for _, test := range tests {
+		b := &tokenBucket{rate: test.rate, burst: test.burst, tokens: test.tokens}
+		b.last = time.Now().Add(-test.elapsed)
+		got := b.Take()
+		// Allow for the time passing while the test runs.
+		if got > test.want || got < test.want-50*time.Millisecond {
+			t.Errorf("%s: Expected wait %s, got %s", test.name, test.want, got)
+		}
+		if throttled := got > 0; b.throttled != throttled {
+			t.Errorf("%s: Expected throttled %v, got %v", test.name, throttled, b.throttled)
+		}
+		if b.tokens > b.burst {
+			t.Errorf("%s: Expected at most %v tokens, got %v", test.name, b.burst, b.tokens)
+		}
+	}
+}
+
+func TestTokenBucketBurst(t *testing.T) {
+	b := newTokenBucket(&Config{MaxRate: 1, RatePeriod: 60, RateBurst: 3})
+	for i := 0; i < 3; i++ {
+		if d := b.Take(); d != 0 {
+			t.Fatalf("Expected token %d of the burst, got wait %s", i+1, d)
+		}
+	}
+	if d := b.Take(); d < 59*time.Second || d > 60*time.Second {
+		t.Errorf("Expected a wait of a minute once the burst is used, got %s", d)
+	}
+}
+
+func TestTokenBucketSetRate(t *testing.T) {
+	tests := []struct {
+		burst, tokens float64
+		wantBurst     float64
+		wantTokens    float64
+	}{
+		{5, 5, 5, 5},
+		{2, 5, 2, 2},
+		{0, 5, 1, 1},
+		{10, 3, 10, 3},
+	}
+	for _, test := range tests {
+		b := &tokenBucket{rate: 1, burst: 5, tokens: test.tokens, last: time.Now()}
+		b.setRate(1, test.burst)
+		if b.burst != test.wantBurst || b.tokens < test.wantTokens || b.tokens > test.wantTokens+0.01 {
+			t.Errorf("Expected burst %v with %v tokens, got %v with %v", test.wantBurst, test.wantTokens, b.burst, b.tokens)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	BackoffExponential = "exponential"
+	BackoffLinear      = "linear"
+	BackoffFixed       = "fixed"
+)
+
+const (
+	JitterNone  = ""
+	JitterFull  = "full"
+	JitterEqual = "equal"
+)
+
+const (
+	DefaultRetryInitialDelay int32   = 2
+	DefaultRetryMultiplier   float64 = 2
+)
+
+// MaxRetryAfter is the longest a target may ask us to wait with Retry-After
+// when the retry policy has no maximum delay.
+const MaxRetryAfter = time.Hour
+
+var (
+	ErrRetryInvalidBackoff    = errors.New("Retry error: invalid backoff")
+	ErrRetryInvalidJitter     = errors.New("Retry error: invalid jitter")
+	ErrRetryInvalidDelay      = errors.New("Retry error: invalid delay")
+	ErrRetryInvalidMultiplier = errors.New("Retry error: invalid multiplier")
+)
+
+// RetryPolicy decides how long a failed task waits before its next try. The
+// zero value is exponential backoff starting at two seconds with no cap and no
+// jitter.
+type RetryPolicy struct {
+	Backoff      string  `json:"backoff"`       // One of exponential, linear or fixed.
+	InitialDelay int32   `json:"initial_delay"` // Delay in seconds before the first retry.
+	MaxDelay     int32   `json:"max_delay"`     // Upper bound on delay in seconds. Set 0 for no limit.
+	Multiplier   float64 `json:"multiplier"`    // Growth factor for exponential backoff.
+	Jitter       string  `json:"jitter"`        // One of full, equal or empty for none.
+}
+
+// Validate checks that the policy only holds known values.
+func (p *RetryPolicy) Validate() error {
+	switch p.Backoff {
+	case "", BackoffExponential, BackoffLinear, BackoffFixed:
+	default:
+		return ErrRetryInvalidBackoff
+	}
+	switch p.Jitter {
+	case JitterNone, JitterFull, JitterEqual:
+	default:
+		return ErrRetryInvalidJitter
+	}
+	if p.InitialDelay < 0 || p.MaxDelay < 0 {
+		return ErrRetryInvalidDelay
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return ErrRetryInvalidMultiplier
+	}
+	return nil
+}
+
+// Delay returns the number of seconds to wait before try number tries, where
+// 1 is the first retry.
+func (p *RetryPolicy) Delay(tries int32) int32 {
+	if tries < 1 {
+		tries = 1
+	}
+	initial := p.InitialDelay
+	if initial == 0 {
+		initial = DefaultRetryInitialDelay
+	}
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	var d float64
+	switch p.Backoff {
+	case BackoffFixed:
+		d = float64(initial)
+	case BackoffLinear:
+		d = float64(initial) * float64(tries)
+	default:
+		d = float64(initial) * math.Pow(multiplier, float64(tries-1))
+	}
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	if d > math.MaxInt32-1 {
+		d = math.MaxInt32 - 1
+	}
+
+	delay := int32(d)
+	switch p.Jitter {
+	case JitterFull:
+		delay = rand.Int31n(delay + 1)
+	case JitterEqual:
+		delay = delay/2 + rand.Int31n(delay/2+1)
+	}
+	return delay
+}
+
+// RetryAfter limits the wait asked for by a target to the maximum delay of
+// the policy, or MaxRetryAfter if it has none.
+func (p *RetryPolicy) RetryAfter(d time.Duration) time.Duration {
+	max := MaxRetryAfter
+	if p.MaxDelay > 0 {
+		max = time.Duration(p.MaxDelay) * time.Second
+	}
+	if d > max {
+		return max
+	}
+	if d < 0 {
+		return 0
+	}
+	return d
+}
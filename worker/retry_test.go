@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		policy RetryPolicy
+		tries  int32
+		want   int32
+	}{
+		{RetryPolicy{}, 0, 2},
+		{RetryPolicy{}, 1, 2},
+		{RetryPolicy{}, 2, 4},
+		{RetryPolicy{}, 5, 32},
+		{RetryPolicy{}, 1000, math.MaxInt32 - 1},
+		{RetryPolicy{InitialDelay: 10, Multiplier: 1.5}, 3, 22},
+		{RetryPolicy{InitialDelay: 10, MaxDelay: 60}, 4, 60},
+		{RetryPolicy{Backoff: BackoffLinear, InitialDelay: 5}, 3, 15},
+		{RetryPolicy{Backoff: BackoffLinear, InitialDelay: 5, MaxDelay: 12}, 3, 12},
+		{RetryPolicy{Backoff: BackoffFixed, InitialDelay: 7}, 9, 7},
+		{RetryPolicy{Backoff: BackoffFixed}, 9, DefaultRetryInitialDelay},
+	}
+	for _, test := range tests {
+		got := test.policy.Delay(test.tries)
+		if got != test.want {
+			t.Errorf("Expected %d for try %d of %+v, got %d", test.want, test.tries, test.policy, got)
+		}
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	tests := []struct {
+		jitter   string
+		min, max int32
+	}{
+		{JitterFull, 0, 40},
+		{JitterEqual, 20, 40},
+	}
+	for _, test := range tests {
+		p := &RetryPolicy{InitialDelay: 40, Backoff: BackoffFixed, Jitter: test.jitter}
+		for i := 0; i < 1000; i++ {
+			got := p.Delay(1)
+			if got < test.min || got > test.max {
+				t.Errorf("Expected %s jitter within %d and %d, got %d", test.jitter, test.min, test.max, got)
+				break
+			}
+		}
+	}
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	tests := []struct {
+		policy RetryPolicy
+		err    error
+	}{
+		{RetryPolicy{}, nil},
+		{RetryPolicy{Backoff: BackoffLinear, Jitter: JitterEqual, InitialDelay: 1, MaxDelay: 10, Multiplier: 1}, nil},
+		{RetryPolicy{Backoff: "random"}, ErrRetryInvalidBackoff},
+		{RetryPolicy{Jitter: "some"}, ErrRetryInvalidJitter},
+		{RetryPolicy{InitialDelay: -1}, ErrRetryInvalidDelay},
+		{RetryPolicy{MaxDelay: -1}, ErrRetryInvalidDelay},
+		{RetryPolicy{Multiplier: 0.5}, ErrRetryInvalidMultiplier},
+	}
+	for _, test := range tests {
+		if err := test.policy.Validate(); err != test.err {
+			t.Errorf("Expected %v for %+v, got %v", test.err, test.policy, err)
+		}
+	}
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	tests := []struct {
+		maxDelay int32
+		wait     time.Duration
+		want     time.Duration
+	}{
+		{0, 30 * time.Second, 30 * time.Second},
+		{0, 90 * time.Minute, MaxRetryAfter},
+		{0, 1 << 62, MaxRetryAfter},
+		{0, -time.Second, 0},
+		{60, 30 * time.Second, 30 * time.Second},
+		{60, 61 * time.Second, 60 * time.Second},
+		{7200, 90 * time.Minute, 90 * time.Minute},
+	}
+	for _, test := range tests {
+		p := &RetryPolicy{MaxDelay: test.maxDelay}
+		got := p.RetryAfter(test.wait)
+		if got != test.want {
+			t.Errorf("Expected %s for %s with max delay %d, got %s", test.want, test.wait, test.maxDelay, got)
+		}
+	}
+}
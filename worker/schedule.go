@@ -33,15 +33,11 @@ type scheduleQueue struct {
 	readFreq time.Duration
 }
 
+// Run hands every task that is due to fn, which moves it out of the line.
 func (s *scheduleQueue) Run(fn func(*Task)) {
 	for {
-		select {
-		case sig := <-s.notifySignal:
-			if sig == stop {
-				log.Infof("queue/%s/scheduler - stopping", s.ID)
-				return
-			}
-		default:
+		if s.checkSignal() {
+			return
 		}
 
 		//log.Debugf("queue/%s/scheduler: tick", s.ID)
@@ -61,11 +57,12 @@ func (s *scheduleQueue) Run(fn func(*Task)) {
 			}
 			//log.Debugf("queue/%s/scheduler - reading key %s", s.ID, k)
 
-			fn(UnserializeTask(k, v))
-			err := s.Delete(k)
+			task, err := UnserializeTask(append([]byte{}, k...), v)
 			if err != nil {
-				panic("2")
+				log.Error(fmt.Sprintf("queue/%s/%s - skipping task %q", s.ID, s.Type, k), err)
+				continue
 			}
+			fn(task)
 		}
 		iter.Close()
 		time.Sleep(s.readFreq)
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	SignatureHeader   = "X-Qdo-Signature"
+	TimestampHeader   = "X-Qdo-Timestamp"
+	MaxSigningSecrets = 2 // The current secret and the one being rotated out.
+)
+
+// Sign returns the signature header value for a request body sent at the
+// given unix time. It holds one v1=<hex> entry per secret, HMAC-SHA256 over
+// "<timestamp>.<body>", so targets keep verifying while a secret is rotated.
+func Sign(secrets []string, timestamp int64, body []byte) string {
+	msg := append([]byte(strconv.FormatInt(timestamp, 10)+"."), body...)
+	res := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(msg)
+		res = append(res, "v1="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	return strings.Join(res, ",")
+}
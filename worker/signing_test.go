@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"foo":"bar"}`)
+	tests := []struct {
+		secrets   []string
+		timestamp int64
+		body      []byte
+		want      string
+	}{
+		{nil, 1400000000, body, ""},
+		{[]string{"secret"}, 1400000000, body,
+			"v1=f2b747a1a9afbef0dd2e6aa2ba03a99def9e77aa2d27496af4fe30e731f63c5a"},
+		{[]string{"secret", "old"}, 1400000000, body,
+			"v1=f2b747a1a9afbef0dd2e6aa2ba03a99def9e77aa2d27496af4fe30e731f63c5a," +
+				"v1=63c5240860486d49b55cc763eaf21a3fe69a1962de382fc6a76752bc9233efb9"},
+		{[]string{"secret"}, 0, nil,
+			"v1=3445798a051818ef95def46c2eb62b43d377ce6e3c29b4d0aec3da0e59577f79"},
+	}
+	for _, test := range tests {
+		got := Sign(test.secrets, test.timestamp, test.body)
+		if got != test.want {
+			t.Errorf("Expected %q for %v at %d, got %q", test.want, test.secrets, test.timestamp, got)
+		}
+	}
+}
+
+func TestHTTPExecutorSigns(t *testing.T) {
+	tests := []struct {
+		secrets []string
+		signed  bool
+	}{
+		{nil, false},
+		{[]string{"secret"}, true},
+		{[]string{"secret", "old"}, true},
+	}
+	for _, test := range tests {
+		var header http.Header
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+		}))
+		e, err := newHTTPExecutor("foo", &Config{TaskTimeout: 5, SigningSecrets: test.secrets})
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+		task := &Task{Target: ts.URL, Payload: `{"foo":"bar"}`}
+		res := e.Execute(task)
+		e.Close()
+		ts.Close()
+		if res.Outcome != OutcomeSuccess {
+			t.Errorf("Expected success, got %+v", res)
+			continue
+		}
+		if !test.signed {
+			if header.Get(SignatureHeader) != "" || header.Get(TimestampHeader) != "" {
+				t.Errorf("Expected no signature, got %v", header)
+			}
+			continue
+		}
+		timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+		if err != nil {
+			t.Errorf("Expected a timestamp, got %q", header.Get(TimestampHeader))
+			continue
+		}
+		want := Sign(test.secrets, timestamp, []byte(task.Payload))
+		if got := header.Get(SignatureHeader); got != want {
+			t.Errorf("Expected signature %q, got %q", want, got)
+		}
+	}
+}
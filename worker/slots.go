@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"sync"
+)
+
+func newSlots(limit int32) *slots {
+	s := &slots{limit: limit}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// slots limits the number of tasks processing at once. Unlike a buffered
+// channel the limit can be changed while tasks are running.
+type slots struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	limit int32
+	used  int32
+}
+
+// Acquire blocks until a slot is free and takes it.
+func (s *slots) Acquire() {
+	s.mu.Lock()
+	for s.used >= s.limit {
+		s.cond.Wait()
+	}
+	s.used++
+	s.mu.Unlock()
+}
+
+// Release frees a slot taken by Acquire.
+func (s *slots) Release() {
+	s.mu.Lock()
+	s.used--
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+// Resize changes the number of slots. Shrinking never interrupts running
+// tasks, new ones wait until enough slots are released.
+func (s *slots) Resize(limit int32) {
+	s.mu.Lock()
+	s.limit = limit
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
@@ -26,9 +26,16 @@ func (i *AtomicInt) String() string {
 type Stats struct {
 	InQueue                   AtomicInt
 	InScheduled               AtomicInt
+	InDead                    AtomicInt
 	InProcessing              AtomicInt
 	TotalReceived             AtomicInt
 	TotalProcessedOK          AtomicInt
 	TotalProcessedError       AtomicInt
 	TotalProcessedRescheduled AtomicInt
+	TotalDead                 AtomicInt
+	TotalExpired              AtomicInt
+	TotalDeferred             AtomicInt
+	TotalRejected             AtomicInt
+	TotalDropped              AtomicInt
+	PendingBytes              AtomicInt
 }
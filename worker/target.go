@@ -0,0 +1,258 @@
+package worker
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PrivateHosts is the host rule standing for loopback, private, link-local
+// (including cloud metadata) and unspecified addresses.
+const PrivateHosts = "private"
+
+var privateNets = []string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+var defaultTargetSchemes = []string{"http", "https"}
+
+var (
+	ErrTargetInvalidScheme  = errors.New("Target error: invalid scheme in policy")
+	ErrTargetInvalidRule    = errors.New("Target error: invalid host rule in policy")
+	ErrTargetInvalidPayload = errors.New("Target error: invalid max payload in policy")
+	ErrTargetScheme         = errors.New("Target error: scheme not allowed")
+	ErrTargetHostDenied     = errors.New("Target error: host not allowed")
+	ErrTargetPayloadSize    = errors.New("Target error: payload too large")
+)
+
+// TargetPolicy restricts where the tasks of a queue may be sent. Host rules
+// are host names, *.domain wildcards, IP addresses, CIDR ranges or private.
+// Rules on addresses are checked against the resolved address when
+// connecting, a denied address is never dialed. The zero value allows http
+// and https to any host. exec has to be listed to run commands.
+type TargetPolicy struct {
+	Schemes    []string `json:"schemes"`     // Allowed URL schemes. Empty for http and https.
+	Allow      []string `json:"allow"`       // Host rules a target must match. Empty for any host.
+	Deny       []string `json:"deny"`        // Host rules a target must not match, checked before allow.
+	MaxPayload int32    `json:"max_payload"` // Maximum payload size in bytes. Set 0 for no limit.
+}
+
+// globalTarget is the policy of all queues, queues can only narrow it.
+var globalTarget = &TargetPolicy{}
+
+// SetTargetPolicy sets the policy applied to all queues.
+func SetTargetPolicy(p *TargetPolicy) error {
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+	globalTarget = p
+	return nil
+}
+
+// GlobalMaxPayload returns the payload limit in bytes of all queues, 0 for
+// none.
+func GlobalMaxPayload() int32 {
+	return globalTarget.MaxPayload
+}
+
+// Validate checks the schemes and host rules of the policy.
+func (p *TargetPolicy) Validate() error {
+	for _, s := range p.Schemes {
+		if s == "" || strings.ToLower(s) != s || strings.ContainsAny(s, ":/ ") {
+			return ErrTargetInvalidScheme
+		}
+	}
+	if _, err := parseHostRules(p.Allow); err != nil {
+		return err
+	}
+	if _, err := parseHostRules(p.Deny); err != nil {
+		return err
+	}
+	if p.MaxPayload < 0 {
+		return ErrTargetInvalidPayload
+	}
+	return nil
+}
+
+// hostRules is a parsed list of host rules.
+type hostRules struct {
+	names []string // Host names, or domains with a leading dot.
+	nets  []*net.IPNet
+}
+
+func parseHostRules(rules []string) (*hostRules, error) {
+	h := &hostRules{}
+	for _, rule := range rules {
+		rule = strings.ToLower(strings.TrimSpace(rule))
+		if rule == PrivateHosts {
+			for _, cidr := range privateNets {
+				_, n, _ := net.ParseCIDR(cidr)
+				h.nets = append(h.nets, n)
+			}
+		} else if _, n, err := net.ParseCIDR(rule); err == nil {
+			h.nets = append(h.nets, n)
+		} else if ip := net.ParseIP(rule); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			h.nets = append(h.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		} else if strings.Trim(rule, "*.") != "" && !strings.ContainsAny(rule, "/:[] ") {
+			h.names = append(h.names, strings.TrimPrefix(rule, "*"))
+		} else {
+			return nil, ErrTargetInvalidRule
+		}
+	}
+	return h, nil
+}
+
+func (h *hostRules) empty() bool {
+	return len(h.names) == 0 && len(h.nets) == 0
+}
+
+func (h *hostRules) matchName(host string) bool {
+	for _, name := range h.names {
+		if host == name || (name[0] == '.' && strings.HasSuffix(host, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *hostRules) matchIP(ip net.IP) bool {
+	for _, n := range h.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkURL checks the scheme and host name of a target. Address rules are
+// left to checkAddr unless the host is an address itself.
+func (p *TargetPolicy) checkURL(u *url.URL) error {
+	if !p.allowsScheme(u.Scheme) {
+		return ErrTargetScheme
+	}
+	if u.Scheme == ExecScheme {
+		// Commands are limited by the commands of the queue instead.
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); ip != nil {
+		return p.checkAddr(host, ip)
+	}
+	allow, _ := parseHostRules(p.Allow)
+	deny, _ := parseHostRules(p.Deny)
+	if deny.matchName(host) {
+		return ErrTargetHostDenied
+	}
+	if !allow.empty() && !allow.matchName(host) && len(allow.nets) == 0 {
+		// Can not be allowed by its address either.
+		return ErrTargetHostDenied
+	}
+	return nil
+}
+
+// schemes returns the schemes the policy allows.
+func (p *TargetPolicy) schemes() []string {
+	if len(p.Schemes) == 0 {
+		return defaultTargetSchemes
+	}
+	return p.Schemes
+}
+
+func (p *TargetPolicy) allowsScheme(scheme string) bool {
+	for _, s := range p.schemes() {
+		if s == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAddr checks the address a host resolved to.
+func (p *TargetPolicy) checkAddr(host string, ip net.IP) error {
+	allow, _ := parseHostRules(p.Allow)
+	deny, _ := parseHostRules(p.Deny)
+	if deny.matchName(host) || deny.matchIP(ip) {
+		return ErrTargetHostDenied
+	}
+	if !allow.empty() && !allow.matchName(host) && !allow.matchIP(ip) {
+		return ErrTargetHostDenied
+	}
+	return nil
+}
+
+// restrictsHosts reports whether the policy has any host rule.
+func (p *TargetPolicy) restrictsHosts() bool {
+	return len(p.Allow) > 0 || len(p.Deny) > 0
+}
+
+// checkTarget checks a task against the global policy and the policy of the
+// queue.
+func checkTarget(task *Task, queue *TargetPolicy) error {
+	u, err := url.Parse(task.Target)
+	if err != nil || u.Scheme == "" {
+		return ErrTaskInvalidTarget
+	}
+	for _, p := range []*TargetPolicy{globalTarget, queue} {
+		if p.MaxPayload > 0 && len(task.Payload) > int(p.MaxPayload) {
+			return ErrTargetPayloadSize
+		}
+		err := p.checkURL(u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dialTarget resolves the host of addr and connects to the first address
+// allowed by the global policy and the policy of the queue. Connecting to the
+// checked address keeps DNS from answering differently on the second lookup.
+func dialTarget(network, addr string, timeout time.Duration, queue *TargetPolicy) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	host = strings.ToLower(host)
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		ips, err = net.LookupIP(host)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, ip := range ips {
+		if globalTarget.checkAddr(host, ip) != nil || queue.checkAddr(host, ip) != nil {
+			continue
+		}
+		return net.DialTimeout(network, net.JoinHostPort(ip.String(), port), timeout)
+	}
+	return nil, ErrTargetHostDenied
+}
+
+// isTargetDenied reports whether a request failed on the target policy.
+func isTargetDenied(err error) bool {
+	if uerr, ok := err.(*url.Error); ok {
+		err = uerr.Err
+	}
+	if oerr, ok := err.(*net.OpError); ok {
+		err = oerr.Err
+	}
+	return err == ErrTargetHostDenied || err == ErrTargetScheme
+}
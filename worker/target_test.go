@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"net"
+	"testing"
+)
+
+func TestParseHostRules(t *testing.T) {
+	tests := []struct {
+		rules []string
+		names int
+		nets  int
+		err   error
+	}{
+		{nil, 0, 0, nil},
+		{[]string{"example.com", "*.example.org", ".example.net"}, 3, 0, nil},
+		{[]string{"10.0.0.0/8", "192.168.1.1", "::1", "fd00::/8"}, 0, 4, nil},
+		{[]string{" Private "}, 0, len(privateNets), nil},
+		{[]string{"*"}, 0, 0, ErrTargetInvalidRule},
+		{[]string{"."}, 0, 0, ErrTargetInvalidRule},
+		{[]string{"example.com:80"}, 0, 0, ErrTargetInvalidRule},
+		{[]string{"http://example.com"}, 0, 0, ErrTargetInvalidRule},
+		{[]string{"10.0.0.0/33"}, 0, 0, ErrTargetInvalidRule},
+	}
+	for _, test := range tests {
+		h, err := parseHostRules(test.rules)
+		if err != test.err {
+			t.Errorf("Expected %v for %v, got %v", test.err, test.rules, err)
+			continue
+		}
+		if err == nil && (len(h.names) != test.names || len(h.nets) != test.nets) {
+			t.Errorf("Expected %d name(s) and %d net(s) for %v, got %v and %v", test.names, test.nets, test.rules, h.names, h.nets)
+		}
+	}
+}
+
+func TestCheckAddr(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy TargetPolicy
+		host   string
+		ip     string
+		err    error
+	}{
+		{"no rules", TargetPolicy{}, "example.com", "93.184.216.34", nil},
+		{"private denied", TargetPolicy{Deny: []string{PrivateHosts}}, "internal", "10.1.2.3", ErrTargetHostDenied},
+		{"loopback denied", TargetPolicy{Deny: []string{PrivateHosts}}, "localhost", "127.0.0.1", ErrTargetHostDenied},
+		{"metadata denied", TargetPolicy{Deny: []string{PrivateHosts}}, "metadata", "169.254.169.254", ErrTargetHostDenied},
+		{"ipv6 loopback denied", TargetPolicy{Deny: []string{PrivateHosts}}, "::1", "::1", ErrTargetHostDenied},
+		{"public passes deny", TargetPolicy{Deny: []string{PrivateHosts}}, "example.com", "93.184.216.34", nil},
+		{"name denied", TargetPolicy{Deny: []string{".example.com"}}, "api.example.com", "93.184.216.34", ErrTargetHostDenied},
+		{"name allowed", TargetPolicy{Allow: []string{"*.example.com"}}, "api.example.com", "10.0.0.1", nil},
+		{"domain is not its subdomain", TargetPolicy{Allow: []string{".example.com"}}, "example.com", "93.184.216.34", ErrTargetHostDenied},
+		{"net allowed", TargetPolicy{Allow: []string{"10.0.0.0/8"}}, "internal", "10.1.2.3", nil},
+		{"outside allowed net", TargetPolicy{Allow: []string{"10.0.0.0/8"}}, "internal", "11.1.2.3", ErrTargetHostDenied},
+		{"deny wins", TargetPolicy{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.1.2.3"}}, "internal", "10.1.2.3", ErrTargetHostDenied},
+	}
+	for _, test := range tests {
+		err := test.policy.checkAddr(test.host, net.ParseIP(test.ip))
+		if err != test.err {
+			t.Errorf("%s: Expected %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestCheckTarget(t *testing.T) {
+	defer func(p *TargetPolicy) { globalTarget = p }(globalTarget)
+	globalTarget = &TargetPolicy{Deny: []string{PrivateHosts}, MaxPayload: 10}
+	tests := []struct {
+		target  string
+		payload string
+		queue   TargetPolicy
+		err     error
+	}{
+		{"http://example.com/", "", TargetPolicy{}, nil},
+		{"https://example.com/", "", TargetPolicy{}, nil},
+		{"example.com", "", TargetPolicy{}, ErrTaskInvalidTarget},
+		{"ftp://example.com/", "", TargetPolicy{}, ErrTargetScheme},
+		{"exec://resize", "", TargetPolicy{}, ErrTargetScheme},
+		{"http://127.0.0.1/", "", TargetPolicy{}, ErrTargetHostDenied},
+		{"http://[::1]:80/", "", TargetPolicy{}, ErrTargetHostDenied},
+		{"http://example.com/", "01234567890", TargetPolicy{}, ErrTargetPayloadSize},
+		{"http://example.com/", "0123456789", TargetPolicy{MaxPayload: 5}, ErrTargetPayloadSize},
+		{"http://example.com/", "", TargetPolicy{Schemes: []string{"https"}}, ErrTargetScheme},
+		{"http://api.example.com/", "", TargetPolicy{Allow: []string{".example.com"}}, nil},
+		{"http://example.org/", "", TargetPolicy{Allow: []string{".example.com"}}, ErrTargetHostDenied},
+		{"http://example.org/", "", TargetPolicy{Allow: []string{"10.0.0.0/8"}}, nil},
+	}
+	for _, test := range tests {
+		err := checkTarget(&Task{Target: test.target, Payload: test.payload}, &test.queue)
+		if err != test.err {
+			t.Errorf("Expected %v for %s with %+v, got %v", test.err, test.target, test.queue, err)
+		}
+	}
+}
@@ -5,115 +5,430 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
-	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/borgenk/qdo/config"
 	"github.com/borgenk/qdo/log"
 )
 
 type Task struct {
-	ID      string `json:"id"`
-	Key     []byte `json:"key"`
-	Target  string `json:"target"`
-	Payload string `json:"payload"`
-	Tries   int32  `json:"tries"`
-	Delay   int32  `json:"delay"`
+	ID           string            `json:"id"`
+	Key          []byte            `json:"key"`
+	Target       string            `json:"target"`
+	Method       string            `json:"method"`
+	Headers      map[string]string `json:"headers"`
+	ContentType  string            `json:"content_type"`
+	Payload      string            `json:"payload"`
+	Tries        int32             `json:"tries"`
+	Delay        int32             `json:"delay"`
+	Status       int32             `json:"status"`         // HTTP status of last attempt, 0 if no response.
+	Priority     int32             `json:"priority"`       // From MinTaskPriority to MaxTaskPriority, higher goes first.
+	ExpiresAt    int64             `json:"expires_at"`     // Unix time after which the task is dropped, 0 for never.
+	PipelineID   string            `json:"pipeline_id"`    // Pipeline the task is a stage of, empty if none.
+	Stage        int32             `json:"pipeline_stage"` // Index of the task's stage in its pipeline.
+	BatchID      string            `json:"batch_id"`       // Batch the task is a member of, empty if none.
+	retryAfter   time.Duration     // Wait requested by the target through Retry-After.
+	attempt      *Attempt          // Result of the last delivery, nil if none was made.
+	response     []byte            // Response body of a pipeline stage, up to MaxStagePayload bytes.
+	responseType string            // Content type of response.
+}
+
+const (
+	DefaultTaskMethod      = "POST"
+	DefaultTaskContentType = "application/json"
+)
+
+const (
+	MinTaskPriority int32 = 0
+	MaxTaskPriority int32 = 9
+)
+
+var taskMethods = map[string]bool{
+	"GET":    true,
+	"POST":   true,
+	"PUT":    true,
+	"PATCH":  true,
+	"DELETE": true,
 }
 
 var (
-	ErrClientBadRequest  = errors.New("Client error: bad request")
-	ErrClientUnkonwn     = errors.New("Client error: unknown")
-	ErrTaskInvalidTarget = errors.New("Task error: invalid task target")
-	ErrTaskMaxTries      = errors.New("Task error: max tries reached")
+	ErrClientBadRequest    = errors.New("Client error: bad request")
+	ErrClientUnkonwn       = errors.New("Client error: unknown")
+	ErrClientRetryAfter    = errors.New("Client error: retry after")
+	ErrTaskInvalidTarget   = errors.New("Task error: invalid task target")
+	ErrTaskInvalidMethod   = errors.New("Task error: invalid task method")
+	ErrTaskInvalidHeader   = errors.New("Task error: invalid task header")
+	ErrTaskMaxTries        = errors.New("Task error: max tries reached")
+	ErrTaskNotFound        = errors.New("Task error: task not found")
+	ErrTaskInvalidID       = errors.New("Task error: invalid task id")
+	ErrTaskAlreadyExist    = errors.New("Task error: task id already in use")
+	ErrTaskInvalidKey      = errors.New("Task error: invalid idempotency key")
+	ErrTaskInvalidPriority = errors.New("Task error: invalid task priority")
+	ErrTaskNotPending      = errors.New("Task error: task is not waiting or scheduled")
+	ErrTaskProcessing      = errors.New("Task error: task is being processed")
+	ErrTaskCancelled       = errors.New("Task error: task cancelled")
+	ErrTaskNotScheduled    = errors.New("Task error: task is not scheduled")
+	ErrTaskNotWaiting      = errors.New("Task error: task is not waiting")
+	ErrTaskInvalidSchedule = errors.New("Task error: scheduled time must be in the future")
+	ErrTaskCorrupt         = errors.New("Task error: stored task is corrupt")
 )
 
-// TASK(4)|tries(4)|delay(4)|sizeOfTarget(4)|target(x)|payload(x)
+var validTaskID = regexp.MustCompile("^[A-Za-z0-9_.-]{1,128}$")
+
+// Normalize fills in default request values and validates the method,
+// headers, priority and client supplied id.
+func (t *Task) Normalize() error {
+	t.Method = strings.ToUpper(t.Method)
+	if t.Method == "" {
+		t.Method = DefaultTaskMethod
+	}
+	if !taskMethods[t.Method] {
+		return ErrTaskInvalidMethod
+	}
+	if t.ContentType == "" {
+		t.ContentType = DefaultTaskContentType
+	}
+	if t.ID != "" && !validTaskID.MatchString(t.ID) {
+		return ErrTaskInvalidID
+	}
+	if t.Priority < MinTaskPriority || t.Priority > MaxTaskPriority {
+		return ErrTaskInvalidPriority
+	}
+	for k, v := range t.Headers {
+		if k == "" || strings.ContainsAny(k, " :\r\n") || strings.ContainsAny(v, "\r\n") {
+			return ErrTaskInvalidHeader
+		}
+	}
+	return nil
+}
+
+// Task records start with a magic and a format version. Records written
+// before the format was versioned start with TASK, see legacyTaskLayouts.
+const (
+	taskMagic         = "QTSK"
+	taskFormatVersion = 1
+	legacyTaskMagic   = "TASK"
+)
+
+// QTSK(4)|version(1)|tries(4)|delay(4)|status(4)|priority(4)|expiresAt(8)|
+// stage(4)|sizeOfPipelineID(4)|pipelineID(x)|sizeOfBatchID(4)|batchID(x)|
+// sizeOfTarget(4)|target(x)|
+// sizeOfMethod(4)|method(x)|sizeOfContentType(4)|contentType(x)|
+// numHeaders(4)|[sizeOfName(4)|name(x)|sizeOfValue(4)|value(x)]...|payload(x)
 func (t *Task) Serialize() []byte {
-	var (
-		out          []byte
-		tries        []byte = make([]byte, 4)
-		delay        []byte = make([]byte, 4)
-		sizeOfTarget []byte = make([]byte, 4)
-	)
-
-	binary.LittleEndian.PutUint32(tries, uint32(t.Tries))
-	binary.LittleEndian.PutUint32(delay, uint32(t.Delay))
-	binary.LittleEndian.PutUint32(sizeOfTarget, uint32(len(t.Target)))
-
-	out = append([]byte("TASK"), tries...)
-	out = append(out, delay...)
-	out = append(out, sizeOfTarget...)
-	out = append(out, []byte(t.Target)...)
+	out := append([]byte(taskMagic), taskFormatVersion)
+	out = appendUint32(out, uint32(t.Tries))
+	out = appendUint32(out, uint32(t.Delay))
+	out = appendUint32(out, uint32(t.Status))
+	out = appendUint32(out, uint32(t.Priority))
+	out = appendUint64(out, uint64(t.ExpiresAt))
+	out = appendUint32(out, uint32(t.Stage))
+	out = appendString(out, t.PipelineID)
+	out = appendString(out, t.BatchID)
+	out = appendString(out, t.Target)
+	out = appendString(out, t.Method)
+	out = appendString(out, t.ContentType)
+	out = appendUint32(out, uint32(len(t.Headers)))
+	for k, v := range t.Headers {
+		out = appendString(out, k)
+		out = appendString(out, v)
+	}
 	out = append(out, []byte(t.Payload)...)
 	return out
 }
 
 func (t *Task) String() string {
 	return "ID: " + t.ID + "\n" +
-		"Target: " + t.Target + "\n" +
+		"Target: " + t.Method + " " + t.Target + "\n" +
+		"Content-Type: " + t.ContentType + "\n" +
 		"Tries: " + strconv.Itoa(int(t.Tries)) + "\n" +
 		"Delay: " + strconv.Itoa(int(t.Delay)) + "\n" +
+		"Status: " + strconv.Itoa(int(t.Status)) + "\n" +
+		"Priority: " + strconv.Itoa(int(t.Priority)) + "\n" +
 		"Payload: " + t.Payload
 }
 
-func (t *Task) Process(queueID *string, client *http.Client, config *Config, stats *Stats) error {
+// Expired reports whether the task is past its deadline at the given time.
+func (t *Task) Expired(now time.Time) bool {
+	return t.ExpiresAt > 0 && now.Unix() >= t.ExpiresAt
+}
+
+// HeaderNames returns the sorted names of the custom request headers.
+func (t *Task) HeaderNames() []string {
+	names := make([]string, 0, len(t.Headers))
+	for k := range t.Headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Process delivers the task once with the executor and records the attempt.
+// It returns how the delivery went, and for anything but success, why.
+func (t *Task) Process(queueID *string, executor Executor, config *Config, stats *Stats) (Outcome, error) {
 	log.Infof("queue/%s/task/%s - processing:\n%s", *queueID, t.ID, t.String())
 
 	_, err := url.Parse(t.Target)
 	if err != nil {
 		// Assume invalid task, discard it.
 		log.Error(fmt.Sprintf("queue/%s/task/%s - invalid target URL", *queueID, t.ID), err)
-		return ErrTaskInvalidTarget
+		return OutcomeFail, ErrTaskInvalidTarget
 	}
 	if config.TaskMaxTries > 0 && t.Tries >= config.TaskMaxTries {
 		// Max tries reached.
 		log.Infof("queue/%s/task/%s - max tries reached (%d)", *queueID, t.ID, t.Tries)
-		return ErrTaskMaxTries
+		return OutcomeFail, ErrTaskMaxTries
+	}
+	if executor == nil {
+		log.Infof("queue/%s/task/%s - no executor for target", *queueID, t.ID)
+		return OutcomeFail, ErrExecutorNotFound
 	}
 
-	resp, err := client.Post(t.Target, "application/json", bytes.NewReader([]byte(t.Payload)))
-	if err == nil {
-		io.Copy(ioutil.Discard, resp.Body)
-		resp.Body.Close()
+	start := time.Now()
+	res := executor.Execute(t)
+	t.Status = res.Status
+	t.retryAfter = 0
+	t.attempt = &Attempt{
+		StartedAt: start,
+		Duration:  int64(time.Since(start) / time.Millisecond),
+		Status:    res.Status,
+	}
+	if len(res.Response) > AttemptResponseLimit {
+		t.attempt.Response = string(res.Response[:AttemptResponseLimit])
+	} else {
+		t.attempt.Response = string(res.Response)
+	}
+	if res.Err != nil {
+		t.attempt.Error = res.Err.Error()
+	}
+	t.response = nil
+	if t.PipelineID != "" {
+		t.response = res.Response
+		t.responseType = res.ContentType
 	}
 
-	if err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		// Task processed successfully.
-		log.Infof("queue/%s/task/%s - completed successfully: %s", *queueID, t.ID, resp.Status)
+	switch res.Outcome {
+	case OutcomeSuccess:
+		log.Infof("queue/%s/task/%s - completed successfully (status %d)", *queueID, t.ID, res.Status)
 		stats.TotalProcessedOK.Add(1)
-		return nil
-	} else if err == nil && resp.StatusCode >= 400 && resp.StatusCode <= 499 {
-		// Bad request, no point in retrying.
-		log.Infof("queue/%s/task/%s - request invalid: %s", *queueID, t.ID, resp.Status)
+		return OutcomeSuccess, nil
+	case OutcomeRetryAfter:
+		// Target is overloaded or rate limiting, retry when it asks us to.
+		log.Infof("queue/%s/task/%s - target unavailable (status %d), retry after %s", *queueID, t.ID, res.Status, res.RetryAfter)
 		stats.TotalProcessedError.Add(1)
-		return ErrClientBadRequest
-	} else if err != nil {
-		// Connection failure, retry later.
-		log.Error(fmt.Sprintf("queue/%s/task/%s - connection failure", *queueID, t.ID), err)
+		t.retryAfter = res.RetryAfter
+		return OutcomeRetryAfter, ErrClientRetryAfter
+	case OutcomeFail:
+		// No point in retrying.
+		err = res.Err
+		if err == nil {
+			err = ErrClientBadRequest
+		}
+		log.Infof("queue/%s/task/%s - failed (status %d), not retrying: %s", *queueID, t.ID, res.Status, err)
 		stats.TotalProcessedError.Add(1)
-		return ErrClientUnkonwn
-	} else {
-		// Unknown error, retry later.
-		log.Infof("queue/%s/task/%s - http status %d", *queueID, t.ID, resp.StatusCode)
+		return OutcomeFail, err
+	default:
+		err = res.Err
+		if err == nil {
+			err = ErrClientUnkonwn
+		}
+		log.Infof("queue/%s/task/%s - failed (status %d), retrying: %s", *queueID, t.ID, res.Status, err)
 		stats.TotalProcessedError.Add(1)
-		return ErrClientUnkonwn
+		return OutcomeRetry, err
 	}
 }
 
-func UnserializeTask(key, value []byte) *Task {
-	task := &Task{}
-	i := bytes.LastIndex(key, []byte(config.Prefix))
-	ts := int32(binary.LittleEndian.Uint32(value[12:16]))
+// ResponseLimit returns the number of output bytes an executor keeps for the
+// task. The output of a pipeline stage is the payload of the next one.
+func (t *Task) ResponseLimit() int {
+	if t.PipelineID != "" {
+		return MaxStagePayload
+	}
+	return AttemptResponseLimit
+}
+
+// taskLayout tells which of the optional fields a task record holds. Fields
+// were only ever added, in the order they are stored in.
+type taskLayout struct {
+	status    bool // status(4)
+	priority  bool // priority(4)
+	expiresAt bool // expiresAt(8)
+	pipeline  bool // stage(4)|sizeOfPipelineID(4)|pipelineID(x)
+	batch     bool // sizeOfBatchID(4)|batchID(x)
+	request   bool // method, content type and headers after the target
+}
+
+// currentTaskLayout is the layout of version 1, with every field.
+var currentTaskLayout = taskLayout{true, true, true, true, true, true}
 
+// legacyTaskLayouts are the layouts of unversioned TASK records, newest
+// first. Their layout is not stored, so a record is read with the first
+// layout it fits.
+var legacyTaskLayouts = []taskLayout{
+	currentTaskLayout,
+	{status: true, priority: true, expiresAt: true, pipeline: true, request: true},
+	{status: true, priority: true, expiresAt: true, request: true},
+	{status: true, priority: true, request: true},
+	{status: true, request: true},
+	{status: true},
+	{},
+}
+
+// UnserializeTask reads a task stored under key. It returns ErrTaskCorrupt
+// if the value is not a task record or is cut short.
+func UnserializeTask(key, value []byte) (*Task, error) {
+	var task *Task
+	switch {
+	case bytes.HasPrefix(value, []byte(taskMagic)):
+		if len(value) < 5 || value[4] != taskFormatVersion {
+			return nil, ErrTaskCorrupt
+		}
+		task = decodeTask(value[5:], currentTaskLayout)
+	case bytes.HasPrefix(value, []byte(legacyTaskMagic)):
+		for _, layout := range legacyTaskLayouts {
+			task = decodeTask(value[4:], layout)
+			if task != nil && task.plausible(layout) {
+				break
+			}
+			task = nil
+		}
+	}
+	if task == nil {
+		return nil, ErrTaskCorrupt
+	}
+	i := bytes.LastIndex(key, []byte(config.Prefix))
 	task.ID = string(key[i+1 : len(key)])
 	task.Key = key
-	task.Tries = int32(binary.LittleEndian.Uint32(value[4:8]))
-	task.Delay = int32(binary.LittleEndian.Uint32(value[8:12]))
-	task.Target = string(value[16 : 16+ts])
-	task.Payload = string(value[16+ts : len(value)])
+	return task, nil
+}
+
+// decodeTask reads the fields following the magic of a task record, or
+// returns nil if the value is too short for the layout.
+func decodeTask(value []byte, layout taskLayout) *Task {
+	task := &Task{}
+	var v uint32
+	n := 0
+	v, n = readUint32(value, n)
+	task.Tries = int32(v)
+	v, n = readUint32(value, n)
+	task.Delay = int32(v)
+	if layout.status {
+		v, n = readUint32(value, n)
+		task.Status = int32(v)
+	}
+	if layout.priority {
+		v, n = readUint32(value, n)
+		task.Priority = int32(v)
+	}
+	if layout.expiresAt {
+		var expiresAt uint64
+		expiresAt, n = readUint64(value, n)
+		task.ExpiresAt = int64(expiresAt)
+	}
+	if layout.pipeline {
+		v, n = readUint32(value, n)
+		task.Stage = int32(v)
+		task.PipelineID, n = readString(value, n)
+	}
+	if layout.batch {
+		task.BatchID, n = readString(value, n)
+	}
+	task.Target, n = readString(value, n)
+	if layout.request {
+		task.Method, n = readString(value, n)
+		task.ContentType, n = readString(value, n)
+		var numHeaders uint32
+		numHeaders, n = readUint32(value, n)
+		if n >= 0 && int(numHeaders) > (len(value)-n)/8 {
+			// Every header takes at least two sizes.
+			return nil
+		}
+		if numHeaders > 0 {
+			task.Headers = make(map[string]string, numHeaders)
+		}
+		for j := 0; j < int(numHeaders) && n >= 0; j++ {
+			var k, v string
+			k, n = readString(value, n)
+			v, n = readString(value, n)
+			task.Headers[k] = v
+		}
+	} else {
+		task.Method = DefaultTaskMethod
+		task.ContentType = DefaultTaskContentType
+	}
+	if n < 0 {
+		return nil
+	}
+	task.Payload = string(value[n:len(value)])
 	return task
 }
+
+// plausible reports whether a task read from a legacy record with the given
+// layout looks like one that was stored, as a record of an older layout read
+// with a newer one gives garbage or runs short.
+func (t *Task) plausible(layout taskLayout) bool {
+	u, err := url.Parse(t.Target)
+	if err != nil || u.Scheme == "" || !strings.Contains(t.Target, "://") {
+		return false
+	}
+	if t.Tries < 0 || t.Delay < 0 || t.Priority < MinTaskPriority || t.Priority > MaxTaskPriority {
+		return false
+	}
+	if layout.request && !taskMethods[t.Method] {
+		return false
+	}
+	return true
+}
+
+// appendUint32 appends v to b in little endian byte order.
+func appendUint32(b []byte, v uint32) []byte {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, v)
+	return append(b, buf...)
+}
+
+// appendUint64 appends v to b in little endian byte order.
+func appendUint64(b []byte, v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, v)
+	return append(b, buf...)
+}
+
+// appendString appends the size of s followed by s itself to b.
+func appendString(b []byte, s string) []byte {
+	b = appendUint32(b, uint32(len(s)))
+	return append(b, []byte(s)...)
+}
+
+// readUint32 reads a value written by appendUint32 at offset n and returns
+// it together with the offset following it. The offset is -1 if value is too
+// short, and stays -1 when passed in again.
+func readUint32(value []byte, n int) (uint32, int) {
+	if n < 0 || len(value)-n < 4 {
+		return 0, -1
+	}
+	return binary.LittleEndian.Uint32(value[n : n+4]), n + 4
+}
+
+// readUint64 reads a value written by appendUint64 like readUint32.
+func readUint64(value []byte, n int) (uint64, int) {
+	if n < 0 || len(value)-n < 8 {
+		return 0, -1
+	}
+	return binary.LittleEndian.Uint64(value[n : n+8]), n + 8
+}
+
+// readString reads a string written by appendString like readUint32.
+func readString(value []byte, n int) (string, int) {
+	var size uint32
+	size, n = readUint32(value, n)
+	if n < 0 || uint64(size) > uint64(len(value)-n) {
+		return "", -1
+	}
+	return string(value[n : n+int(size)]), n + int(size)
+}
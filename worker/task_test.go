@@ -0,0 +1,236 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/borgenk/qdo/log"
+)
+
+// legacyRecord encodes a task the way the unversioned TASK format with the
+// given layout stored it.
+func legacyRecord(t *Task, layout taskLayout) []byte {
+	out := []byte(legacyTaskMagic)
+	out = appendUint32(out, uint32(t.Tries))
+	out = appendUint32(out, uint32(t.Delay))
+	if layout.status {
+		out = appendUint32(out, uint32(t.Status))
+	}
+	if layout.priority {
+		out = appendUint32(out, uint32(t.Priority))
+	}
+	if layout.expiresAt {
+		out = appendUint64(out, uint64(t.ExpiresAt))
+	}
+	if layout.pipeline {
+		out = appendUint32(out, uint32(t.Stage))
+		out = appendString(out, t.PipelineID)
+	}
+	if layout.batch {
+		out = appendString(out, t.BatchID)
+	}
+	out = appendString(out, t.Target)
+	if layout.request {
+		out = appendString(out, t.Method)
+		out = appendString(out, t.ContentType)
+		out = appendUint32(out, uint32(len(t.Headers)))
+		for k, v := range t.Headers {
+			out = appendString(out, k)
+			out = appendString(out, v)
+		}
+	}
+	return append(out, []byte(t.Payload)...)
+}
+
+func testTask() *Task {
+	return &Task{
+		ID:          "abc",
+		Key:         []byte("q\x00foo\x00w\x00" + "51400000000" + "00000\x00abc"),
+		Target:      "http://127.0.0.1/mytask",
+		Method:      "PUT",
+		Headers:     map[string]string{"X-Foo": "bar", "Authorization": "Bearer x"},
+		ContentType: "text/plain",
+		Payload:     "{'foo': 'bar'}",
+		Tries:       3,
+		Delay:       20,
+		Status:      503,
+		Priority:    5,
+		ExpiresAt:   1400000000,
+		PipelineID:  "pipe",
+		Stage:       2,
+		BatchID:     "report-42",
+	}
+}
+
+func TestTaskSerializeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		task *Task
+	}{
+		{"full", testTask()},
+		{"empty", &Task{ID: "x", Key: []byte("k\x00x")}},
+		{"binary payload", &Task{ID: "x", Key: []byte("k\x00x"), Target: "exec://resize", Payload: "\x00\xff\x00TASK"}},
+	}
+	for _, test := range tests {
+		got, err := UnserializeTask(test.task.Key, test.task.Serialize())
+		if err != nil {
+			t.Errorf("%s: Expected no error, got %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.task) {
+			t.Errorf("%s: Expected %+v, got %+v", test.name, test.task, got)
+		}
+	}
+}
+
+func TestUnserializeLegacyTask(t *testing.T) {
+	full := testTask()
+	tests := []struct {
+		name   string
+		layout taskLayout
+		want   func(*Task)
+	}{
+		{"batch id", legacyTaskLayouts[0], func(t *Task) {}},
+		{"pipeline", legacyTaskLayouts[1], func(t *Task) { t.BatchID = "" }},
+		{"expiry", legacyTaskLayouts[2], func(t *Task) { t.BatchID, t.PipelineID, t.Stage = "", "", 0 }},
+		{"priority", legacyTaskLayouts[3], func(t *Task) { t.BatchID, t.PipelineID, t.Stage, t.ExpiresAt = "", "", 0, 0 }},
+		{"request fields", legacyTaskLayouts[4], func(t *Task) {
+			t.BatchID, t.PipelineID, t.Stage, t.ExpiresAt, t.Priority = "", "", 0, 0, 0
+		}},
+		{"status", legacyTaskLayouts[5], func(t *Task) {
+			t.BatchID, t.PipelineID, t.Stage, t.ExpiresAt, t.Priority = "", "", 0, 0, 0
+			t.Method, t.ContentType, t.Headers = DefaultTaskMethod, DefaultTaskContentType, nil
+		}},
+		{"baseline", legacyTaskLayouts[6], func(t *Task) {
+			t.BatchID, t.PipelineID, t.Stage, t.ExpiresAt, t.Priority, t.Status = "", "", 0, 0, 0, 0
+			t.Method, t.ContentType, t.Headers = DefaultTaskMethod, DefaultTaskContentType, nil
+		}},
+	}
+	for _, test := range tests {
+		want := testTask()
+		test.want(want)
+		got, err := UnserializeTask(full.Key, legacyRecord(want, test.layout))
+		if err != nil {
+			t.Errorf("%s: Expected no error, got %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Expected %+v, got %+v", test.name, want, got)
+		}
+	}
+}
+
+func TestUnserializeBaselineTask(t *testing.T) {
+	// TASK|tries|delay|sizeOfTarget|target|payload as stored before tasks
+	// had a status.
+	value := []byte("TASK" +
+		"\x02\x00\x00\x00" + "\x0a\x00\x00\x00" +
+		"\x11\x00\x00\x00" + "http://127.0.0.1/" +
+		"{'foo': 'bar'}")
+	got, err := UnserializeTask([]byte("q\x00foo\x00s\x001400000000\x00abc"), value)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if got.ID != "abc" || got.Tries != 2 || got.Delay != 10 || got.Target != "http://127.0.0.1/" ||
+		got.Payload != "{'foo': 'bar'}" || got.Method != DefaultTaskMethod {
+		t.Errorf("Expected baseline task, got %+v", got)
+	}
+}
+
+func TestUnserializeCorruptTask(t *testing.T) {
+	task := testTask()
+	values := [][]byte{
+		nil,
+		[]byte("JUNK"),
+		append([]byte(taskMagic), taskFormatVersion+1),
+	}
+	// Every cut short record, current and legacy.
+	for _, record := range [][]byte{task.Serialize(), legacyRecord(task, currentTaskLayout)} {
+		for n := 0; n < len(record)-len(task.Payload); n++ {
+			values = append(values, record[:n])
+		}
+	}
+	for _, value := range values {
+		got, err := UnserializeTask(task.Key, value)
+		if err != ErrTaskCorrupt {
+			t.Errorf("Expected ErrTaskCorrupt for %q, got %+v, %v", value, got, err)
+		}
+	}
+}
+
+func TestUnserializeEmbeddedTask(t *testing.T) {
+	task := testTask()
+	legacy := legacyRecord(task, legacyTaskLayouts[0])
+
+	dead := &DeadTask{Task: *task, Reason: "gone", DiedAt: 1400000000}
+	for _, value := range [][]byte{dead.Serialize(), append(dead.Serialize()[:len(dead.Serialize())-len(task.Serialize())], legacy...)} {
+		got, err := UnserializeDeadTask(task.Key, value)
+		if err != nil || !reflect.DeepEqual(got, dead) {
+			t.Errorf("Expected %+v, got %+v, %v", dead, got, err)
+		}
+	}
+	if _, err := UnserializeDeadTask(task.Key, dead.Serialize()[:10]); err == nil {
+		t.Errorf("Expected error for cut short dead task")
+	}
+
+	job := &CronJob{ID: "nightly", Schedule: "@daily", Timezone: "UTC", Missed: "once", TTL: 60, NextRun: 1400000000}
+	job.Task = *task
+	job.Task.ID, job.Task.Key = "", nil
+	got, err := UnserializeCronJob(job.Serialize())
+	if err != nil || !reflect.DeepEqual(got, job) {
+		t.Errorf("Expected %+v, got %+v, %v", job, got, err)
+	}
+	if _, err := UnserializeCronJob(job.Serialize()[:20]); err == nil {
+		t.Errorf("Expected error for cut short cron job")
+	}
+
+	entry := serializeIdempotencyEntry(task, time.Unix(1400000000, 0))
+	res, createdAt, err := unserializeIdempotencyEntry(entry)
+	if err != nil || res.ID != task.ID || res.Target != task.Target || createdAt.Unix() != 1400000000 {
+		t.Errorf("Expected idempotency entry of %s, got %+v, %v", task.ID, res, err)
+	}
+	if _, _, err := unserializeIdempotencyEntry(entry[:8]); err == nil {
+		t.Errorf("Expected error for cut short idempotency entry")
+	}
+}
+
+// resultExecutor answers every task with the same result.
+type resultExecutor struct {
+	res *Result
+}
+
+func (e *resultExecutor) Execute(t *Task) *Result { return e.res }
+func (e *resultExecutor) Close()                  {}
+
+func TestTaskProcess(t *testing.T) {
+	log.InitLog(log.New())
+	queueID := "foo"
+	ok := &resultExecutor{&Result{Outcome: OutcomeSuccess, Status: 200}}
+	tests := []struct {
+		name     string
+		target   string
+		tries    int32
+		maxTries int32
+		executor Executor
+		outcome  Outcome
+		err      error
+	}{
+		{"success", "http://127.0.0.1/", 0, 3, ok, OutcomeSuccess, nil},
+		{"invalid target", "http://[::1/", 0, 0, ok, OutcomeFail, ErrTaskInvalidTarget},
+		{"invalid target with tries", "http://[::1/", 0, 3, ok, OutcomeFail, ErrTaskInvalidTarget},
+		{"max tries", "http://127.0.0.1/", 3, 3, ok, OutcomeFail, ErrTaskMaxTries},
+		{"unlimited tries", "http://127.0.0.1/", 100, 0, ok, OutcomeSuccess, nil},
+		{"no executor", "http://127.0.0.1/", 0, 3, nil, OutcomeFail, ErrExecutorNotFound},
+		{"fail", "http://127.0.0.1/", 0, 3, &resultExecutor{&Result{Outcome: OutcomeFail, Status: 400}}, OutcomeFail, ErrClientBadRequest},
+		{"retry", "http://127.0.0.1/", 0, 3, &resultExecutor{&Result{Outcome: OutcomeRetry, Status: 500}}, OutcomeRetry, ErrClientUnkonwn},
+	}
+	for _, test := range tests {
+		task := &Task{ID: "abc", Target: test.target, Tries: test.tries}
+		config := &Config{MaxConcurrent: 1, TaskMaxTries: test.maxTries}
+		outcome, err := task.Process(&queueID, test.executor, config, &Stats{})
+		if outcome != test.outcome || err != test.err {
+			t.Errorf("%s: Expected %v, %v, got %v, %v", test.name, test.outcome, test.err, outcome, err)
+		}
+	}
+}
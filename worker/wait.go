@@ -4,16 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sync"
+	"sync/atomic"
 	"time"
 
-	_ "github.com/borgenk/qdo/log"
+	"github.com/borgenk/qdo/log"
 	"github.com/borgenk/qdo/store"
 )
 
 func NewWaitQueue(ID string, config *Config, total *AtomicInt, db store.Store, notifySignal chan systemSignal, prefix, suffix []byte) *waitQueue {
-	var locker sync.Mutex
-
 	wq := &waitQueue{
 		queueLine: queueLine{
 			ID:           ID,
@@ -25,93 +23,211 @@ func NewWaitQueue(ID string, config *Config, total *AtomicInt, db store.Store, n
 			suffix:       suffix,
 			total:        total,
 		},
-		notifyReady: make(chan int, config.MaxConcurrent),
-		rewind:      sync.NewCond(&locker),
+		ready:  newSlots(config.MaxConcurrent),
+		limit:  newTokenBucket(config),
+		rewind: make(chan struct{}, 1),
 	}
 	wq.counterTime.Set(0)
 	wq.counter.Set(0)
 	return wq
 }
 
+// waitQueue holds tasks ready to be processed. Tasks are kept in one band per
+// priority, see bandPrefix, and bands are served by weighted round robin so
+// that higher priorities go first while lower ones still move.
 type waitQueue struct {
 	queueLine
-	notifyReady chan int
-	rewind      *sync.Cond
+	ready       *slots
+	limit       *tokenBucket
+	rewind      chan struct{}
 	counterTime AtomicInt
 	counter     AtomicInt
+	holdUntil   AtomicInt // Unix time in nanoseconds dispatch is held until.
+}
+
+// bandPrefix returns the key prefix of a priority band.
+// Key format: [line id] \x00 [key type] \x00 [priority][order] \x00 [task id]
+func (w *waitQueue) bandPrefix(priority int32) []byte {
+	k := make([]byte, 0, len(w.prefix)+1)
+	k = append(k, w.prefix...)
+	return append(k, byte('0'+priority))
+}
+
+// bandWeight is the number of tasks taken from a band per round.
+func bandWeight(priority int32) int {
+	return int(priority) + 1
 }
 
 func (w *waitQueue) Run(fn func(*Task)) {
-	var (
-		iter store.Iterator
-		k    []byte
-		v    []byte
-	)
+	// Last dispatched key per band. Dispatched tasks stay in the line until
+	// processed so reading continues after them.
+	last := make(map[int32][]byte)
 
 	for {
-		select {
-		case <-w.notifySignal:
-			return
-		default:
+		if w.getConfig().Mode == ModePull {
+			// Consumers lease tasks themselves, wait until the mode changes.
+			select {
+			case sig := <-w.notifySignal:
+				if w.handleSignal(sig) {
+					return
+				}
+			case <-w.rewind:
+			}
+			continue
 		}
 
-		iter = w.db.NewIterator(nil)
+		dispatched := 0
+		for priority := MaxTaskPriority; priority >= MinTaskPriority; priority-- {
+			tasks := w.read(priority, last[priority], bandWeight(priority))
+			for _, task := range tasks {
+				if w.checkSignal() {
+					return
+				}
+
+				//log.Debugf("queue/%s/waitinglist: reading key %s", w.ID, task.Key)
 
-		if k != nil {
-			// Seek to last read key.
-			iter.Seek(k)
-			if bytes.Compare(iter.Key(), k) != 0 {
-				// Last read key is deleted and it's now pointing to next key,
-				// go one step back so for loop goes into right position.
-				iter.Prev()
+				// Block until conveyor is ready to process next task.
+				w.ready.Acquire()
+
+				// The queue might have been paused or stopped while blocking,
+				// a target might have asked us to hold off and the rate limit
+				// might be reached.
+				if w.checkSignal() || w.waitHold() || w.waitRate() {
+					w.ready.Release()
+					return
+				}
+
+				fn(task)
+				last[priority] = task.Key
+				dispatched++
 			}
-		} else {
-			iter.Seek(w.prefix)
-			iter.Prev()
+		}
+		if dispatched > 0 {
+			continue
 		}
 
-		for iter.Next() {
-			select {
-			case <-w.notifySignal:
+		//log.Debugf("queue/%s/waitinglist: waiting on signal", w.ID)
+
+		select {
+		case sig := <-w.notifySignal:
+			if w.handleSignal(sig) {
 				return
-			default:
 			}
+		case <-w.rewind:
+		}
+	}
+}
 
-			k = iter.Key()
-			v = iter.Value()
+// setConfig switches the line to a new configuration. Running tasks keep
+// their slots when the concurrency limit shrinks.
+func (w *waitQueue) setConfig(config *Config) {
+	w.queueLine.setConfig(config)
+	w.ready.Resize(config.MaxConcurrent)
+	w.limit.setConfig(config)
+}
 
-			if bytes.Compare(k, w.suffix) > 0 {
-				// End of list reached.
-				break
+// waitHold blocks while dispatch is held by a target. It returns true if the
+// line must stop.
+func (w *waitQueue) waitHold() bool {
+	for {
+		d := w.HeldFor()
+		if d <= 0 {
+			return false
+		}
+		select {
+		case sig := <-w.notifySignal:
+			if w.handleSignal(sig) {
+				return true
 			}
+		case <-time.After(d):
+		}
+	}
+}
 
-			//log.Debugf("queue/%s/waitinglist: reading key %s", w.ID, k)
-
-			// Block until conveyor is ready to process next task.
-			w.notifyReady <- 1
-
-			fn(UnserializeTask(k, v))
-
-			// Throttle task invocations per second (processing + maxRate for now).
-			if w.config.MaxRate > 0 {
-				time.Sleep(time.Duration(int64(time.Second) / int64(w.config.MaxRate)))
+// waitRate blocks until the rate limiter hands out a token. It returns true if
+// the line must stop.
+func (w *waitQueue) waitRate() bool {
+	for {
+		d := w.limit.Take()
+		if d <= 0 {
+			return false
+		}
+		select {
+		case sig := <-w.notifySignal:
+			if w.handleSignal(sig) {
+				return true
 			}
+		case <-time.After(d):
 		}
+	}
+}
 
-		//log.Debugf("queue/%s/waitinglist: waiting on signal", w.ID)
+// read returns up to limit tasks from a priority band stored after key
+// after, or from the start of the band if after is nil.
+func (w *waitQueue) read(priority int32, after []byte, limit int) []*Task {
+	prefix := w.bandPrefix(priority)
+	res := []*Task{}
 
-		iter.Close()
+	iter := w.db.NewIterator(nil)
+	defer iter.Close()
+	if after != nil {
+		iter.Seek(after)
+		if iter.Valid() && bytes.Equal(iter.Key(), after) {
+			iter.Next()
+		}
+	} else {
+		iter.Seek(prefix)
+	}
+	for ; iter.Valid() && len(res) < limit; iter.Next() {
+		if !bytes.HasPrefix(iter.Key(), prefix) {
+			// End of band reached.
+			break
+		}
+		k := append([]byte{}, iter.Key()...)
+		task, err := UnserializeTask(k, iter.Value())
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/%s - skipping task %q", w.ID, w.Type, k), err)
+			continue
+		}
+		res = append(res, task)
+	}
+	return res
+}
 
-		w.rewind.L.Lock()
-		w.rewind.Wait()
-		w.rewind.L.Unlock()
+// GetAll returns up to 100 waiting tasks in dispatch order, highest priority
+// first.
+func (w *waitQueue) GetAll() (*[]Task, error) {
+	limit := 100
+	result := []Task{}
+	for priority := MaxTaskPriority; priority >= MinTaskPriority && len(result) < limit; priority-- {
+		for _, task := range w.read(priority, nil, limit-len(result)) {
+			result = append(result, *task)
+		}
 	}
+	return &result, nil
 }
 
 func (w *waitQueue) Add(task *Task) error {
+	order, err := w.order(task)
+	if err != nil {
+		return err
+	}
+	err = w.add(task, order)
+	if err != nil {
+		return err
+	}
+
+	// Signal new task to queue reader.
+	w.Trigger()
+	return nil
+}
+
+// order returns the order of a new task within the line: its priority, the
+// time it is added and a count of the tasks added before it that second.
+func (w *waitQueue) order(task *Task) (string, error) {
 	now := time.Now().Unix()
 	if w.counter.Get() > 99999 {
-		return errors.New("Too many tasks at once")
+		return "", errors.New("Too many tasks at once")
 	}
 	if now != w.counterTime.Get() {
 		w.counterTime.Set(now)
@@ -119,18 +235,42 @@ func (w *waitQueue) Add(task *Task) error {
 	} else {
 		w.counter.Add(1)
 	}
-	err := w.add(task, fmt.Sprintf("%d%05d", now, w.counter.Get()))
-	if err != nil {
-		return err
+	return fmt.Sprintf("%d%d%05d", task.Priority, now, w.counter.Get()), nil
+}
+
+// Trigger wakes up the queue reader if it is waiting for new tasks. A trigger
+// while the reader is busy is remembered so no wakeup is lost.
+func (w *waitQueue) Trigger() {
+	select {
+	case w.rewind <- struct{}{}:
+	default:
 	}
+}
 
-	// Signal new task to queue reader.
-	w.Trigger()
-	return nil
+// Hold stops dispatching tasks until the given time. An earlier hold is only
+// ever extended, never shortened.
+func (w *waitQueue) Hold(until time.Time) {
+	for {
+		current := w.holdUntil.Get()
+		if until.UnixNano() <= current {
+			return
+		}
+		if atomic.CompareAndSwapInt64((*int64)(&w.holdUntil), current, until.UnixNano()) {
+			return
+		}
+	}
 }
 
-func (w *waitQueue) Trigger() {
-	w.rewind.L.Lock()
-	w.rewind.Signal()
-	w.rewind.L.Unlock()
+// Release ends a hold on dispatching tasks.
+func (w *waitQueue) Release() {
+	w.holdUntil.Set(0)
+}
+
+// HeldFor returns the remaining time dispatch is held, or zero if not held.
+func (w *waitQueue) HeldFor() time.Duration {
+	d := time.Duration(w.holdUntil.Get() - time.Now().UnixNano())
+	if d < 0 {
+		return 0
+	}
+	return d
 }
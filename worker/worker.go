@@ -1,10 +1,11 @@
 package worker
 
 import (
+	"bytes"
 	"crypto/sha1"
+	"errors"
 	"fmt"
-	"net"
-	stdhttp "net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -25,10 +26,115 @@ const (
 )
 
 type Config struct {
-	MaxConcurrent int32 `json:"max_concurrent"` // Number of simultaneous workers processing tasks.
-	MaxRate       int32 `json:"max_rate"`       // Number of maxium task invocations from queue per second.
-	TaskTimeout   int32 `json:"task_timeout"`   // Duration allowed per task to complete in seconds.
-	TaskMaxTries  int32 `json:"task_max_tries"` // Number of tries per task before giving up. Set 0 for unlimited retries.
+	MaxConcurrent     int32             `json:"max_concurrent"`     // Number of simultaneous workers processing tasks.
+	MaxRate           int32             `json:"max_rate"`           // Number of maxium task invocations from queue per rate period. Set 0 for no limit.
+	RatePeriod        int32             `json:"rate_period"`        // Seconds MaxRate applies to. Set 0 for one second.
+	RateBurst         int32             `json:"rate_burst"`         // Number of task invocations allowed at once after an idle period. Set 0 for one.
+	TaskTimeout       int32             `json:"task_timeout"`       // Duration allowed per task to complete in seconds.
+	TaskMaxTries      int32             `json:"task_max_tries"`     // Number of tries per task before giving up. Set 0 for unlimited retries.
+	Retry             RetryPolicy       `json:"retry"`              // Delay between tries of a failed task.
+	RetryAfterHold    bool              `json:"retry_after_hold"`   // Hold all dispatch from the queue while a target asks us to back off.
+	HistoryRetention  int32             `json:"history_retention"`  // Seconds to keep delivery attempts. Set 0 to keep forever.
+	IdempotencyWindow int32             `json:"idempotency_window"` // Seconds an idempotency key is remembered. Set 0 for one day.
+	SigningSecrets    []string          `json:"-"`                  // Secrets requests are signed with, newest first. Never returned by the API.
+	Mode              string            `json:"mode"`               // One of push (default), where tasks are sent to their target, or pull, where consumers lease them.
+	Target            TargetPolicy      `json:"target"`             // Where tasks may be sent, on top of the global policy.
+	Executor          string            `json:"executor"`           // Name of the registered executor delivering tasks, empty to pick one by target scheme.
+	ExecutorOptions   map[string]string `json:"executor_options"`   // Options of the executor, as it defines them.
+	Commands          []string          `json:"commands"`           // Names of the daemon's commands exec:// targets of the queue may run.
+	ExitNoRetry       []int32           `json:"exit_no_retry"`      // Exit codes of commands that are not retried.
+	BreakerThreshold  int32             `json:"breaker_threshold"`  // Consecutive failures of a target host that stop dispatch to it. Set 0 for no breaker.
+	BreakerCooldown   int32             `json:"breaker_cooldown"`   // Seconds dispatch to a failing host stops before a probe is sent. Set 0 for 30 seconds.
+	MaxPending        int32             `json:"max_pending"`        // Number of waiting and scheduled tasks the queue holds. Set 0 for no limit.
+	MaxPendingBytes   int64             `json:"max_pending_bytes"`  // Payload bytes the waiting and scheduled tasks may hold. Set 0 for no limit.
+	Overflow          string            `json:"overflow"`           // One of reject (default), failing new tasks when full, or drop-oldest.
+}
+
+var (
+	ErrConfigInvalidConcurrency = errors.New("Config error: max concurrent must be at least 1")
+	ErrConfigInvalidRate        = errors.New("Config error: invalid max rate")
+	ErrConfigInvalidBurst       = errors.New("Config error: invalid rate burst")
+	ErrConfigInvalidTimeout     = errors.New("Config error: invalid task timeout")
+	ErrConfigInvalidMaxTries    = errors.New("Config error: invalid task max tries")
+	ErrConfigInvalidRetention   = errors.New("Config error: invalid history retention")
+	ErrConfigInvalidWindow      = errors.New("Config error: invalid idempotency window")
+	ErrConfigTooManySecrets     = errors.New("Config error: too many signing secrets")
+	ErrConfigInvalidMode        = errors.New("Config error: invalid mode")
+	ErrConfigInvalidExecutor    = errors.New("Config error: unknown executor")
+	ErrConfigInvalidOption      = errors.New("Config error: unknown executor option")
+	ErrConfigInvalidBreaker     = errors.New("Config error: invalid circuit breaker")
+)
+
+// Validate checks that the configuration can run a queue.
+func (c *Config) Validate() error {
+	if c.MaxConcurrent < 1 {
+		return ErrConfigInvalidConcurrency
+	}
+	if c.MaxRate < 0 || c.RatePeriod < 0 {
+		return ErrConfigInvalidRate
+	}
+	if c.RateBurst < 0 {
+		return ErrConfigInvalidBurst
+	}
+	if c.TaskTimeout < 0 {
+		return ErrConfigInvalidTimeout
+	}
+	if c.TaskMaxTries < 0 {
+		return ErrConfigInvalidMaxTries
+	}
+	if c.HistoryRetention < 0 {
+		return ErrConfigInvalidRetention
+	}
+	if c.IdempotencyWindow < 0 {
+		return ErrConfigInvalidWindow
+	}
+	if c.BreakerThreshold < 0 || c.BreakerCooldown < 0 {
+		return ErrConfigInvalidBreaker
+	}
+	err := c.validateDepth()
+	if err != nil {
+		return err
+	}
+	if len(c.SigningSecrets) > MaxSigningSecrets {
+		return ErrConfigTooManySecrets
+	}
+	switch c.Mode {
+	case "", ModePush, ModePull:
+	default:
+		return ErrConfigInvalidMode
+	}
+	err = c.Target.Validate()
+	if err != nil {
+		return err
+	}
+	err = c.validateCommands()
+	if err != nil {
+		return err
+	}
+	err = c.validateExitCodes()
+	if err != nil {
+		return err
+	}
+	// Executors check their own options.
+	s, err := newExecutorSet("", c)
+	if err != nil {
+		return err
+	}
+	s.Close()
+	return c.Retry.Validate()
+}
+
+// Rate returns the number of task invocations allowed per second, or zero for
+// no limit.
+func (c *Config) Rate() float64 {
+	if c.MaxRate <= 0 {
+		return 0
+	}
+	period := c.RatePeriod
+	if period <= 0 {
+		period = 1
+	}
+	return float64(c.MaxRate) / float64(period)
 }
 
 // NewQueue creates a new queue ready to handle tasks after running
@@ -46,17 +152,35 @@ type QueueManager struct {
 	ID                      string
 	CreatedAt               time.Time
 	Config                  *Config
+	Paused                  bool // Guarded by configMu, see IsPaused.
 	stats                   *Stats
 	statsAddQuantile        *quantile.Stream
 	statsProcessingQuantile *quantile.Stream
 	db                      store.Store
-	httpClient              *stdhttp.Client
+	executors               *executorSet
+	configMu                sync.RWMutex
 	newTaskID               chan string
-	notifySignal            chan systemSignal
+	lineSignals             []chan systemSignal
 	mWaitGroup              *sync.WaitGroup
 	qmWaitGroup             *sync.WaitGroup
 	waitQueue               *waitQueue
 	scheduleQueue           *scheduleQueue
+	deadQueue               *deadQueue
+	index                   *taskIndex
+	taskMu                  sync.Mutex
+	processing              map[string]bool // Keys of waiting tasks being processed.
+	trimSignal              chan struct{}
+	callbackSignal          chan struct{}
+	history                 *taskHistory
+	idempotency             *idempotencyIndex
+	idempotencyMu           sync.Mutex
+	leaseMu                 sync.Mutex
+	crons                   *cronTable
+	cronMu                  sync.Mutex
+	batches                 *batchTable
+	batchMu                 sync.Mutex
+	breakers                *breakerTable
+	quit                    chan struct{}
 }
 
 func (q *QueueManager) Initialize(db store.Store, mWaitGroup *sync.WaitGroup) *QueueManager {
@@ -64,8 +188,22 @@ func (q *QueueManager) Initialize(db store.Store, mWaitGroup *sync.WaitGroup) *Q
 
 	q.stats = &Stats{}
 
-	// Signals events to queue lines (i.e. stop).
-	q.notifySignal = make(chan systemSignal)
+	// Signals events to running queue lines (i.e. pause, resume and stop),
+	// one channel per line.
+	q.lineSignals = []chan systemSignal{
+		make(chan systemSignal, 8),
+		make(chan systemSignal, 8),
+	}
+
+	// Closed when the queue stops, ends background jobs.
+	q.quit = make(chan struct{})
+
+	// Wakes up the trimmer when tasks are added to a queue dropping its
+	// oldest tasks when full.
+	q.trimSignal = make(chan struct{}, 1)
+
+	// Wakes up the callback sender when a batch with a callback is done.
+	q.callbackSignal = make(chan struct{}, 1)
 
 	// Mananger wait group.
 	q.mWaitGroup = mWaitGroup
@@ -76,8 +214,8 @@ func (q *QueueManager) Initialize(db store.Store, mWaitGroup *sync.WaitGroup) *Q
 	// Initialize internal queue lines.
 	q.initInternalQueues()
 
-	// Initialize HTTP client.
-	q.initHTTPClient()
+	// Initialize executors delivering tasks.
+	q.initExecutors()
 
 	// Initialize quantile stats.
 	q.statsAddQuantile = quantile.NewTargeted(0.50, 0.90, 0.99)
@@ -95,64 +233,299 @@ func (q *QueueManager) Initialize(db store.Store, mWaitGroup *sync.WaitGroup) *Q
 		}
 	}()
 
-	// TODO: Fill stats (in waiting, in schedule, etc)
-
 	return q
 }
 
-// initInternalQueues initializes the internal queue lines; wait and
-// schedule queue.
+// initInternalQueues initializes the internal queue lines; wait, schedule
+// and dead queue.
 func (q *QueueManager) initInternalQueues() {
-	q.waitQueue = NewWaitQueue(q.ID, q.Config, &q.stats.InQueue, q.db, q.notifySignal,
+	q.waitQueue = NewWaitQueue(q.ID, q.Config, &q.stats.InQueue, q.db, q.lineSignals[0],
 		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.WaitQueueKey+config.Prefix),
 		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.WaitQueueKey+config.Suffix))
 
-	q.scheduleQueue = NewScheduleQueue(q.ID, q.Config, &q.stats.InScheduled, q.db, q.notifySignal,
+	q.scheduleQueue = NewScheduleQueue(q.ID, q.Config, &q.stats.InScheduled, q.db, q.lineSignals[1],
 		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.ScheduleQueueKey+config.Prefix),
 		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.ScheduleQueueKey+config.Suffix))
+
+	q.deadQueue = NewDeadQueue(q.ID, q.Config, &q.stats.InDead, q.db, nil,
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.DeadQueueKey+config.Prefix),
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.DeadQueueKey+config.Suffix))
+
+	q.index = NewTaskIndex(q.ID, q.db,
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.TaskIndexKey+config.Prefix),
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.TaskIndexKey+config.Suffix))
+	q.waitQueue.index = q.index
+	q.scheduleQueue.index = q.index
+	q.waitQueue.paused = q.IsPaused
+	q.scheduleQueue.paused = q.IsPaused
+	q.waitQueue.bytes = &q.stats.PendingBytes
+	q.scheduleQueue.bytes = &q.stats.PendingBytes
+	q.processing = make(map[string]bool)
+
+	q.history = NewTaskHistory(q.ID, q.db,
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.HistoryKey+config.Prefix),
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.HistoryKey+config.Suffix))
+
+	q.idempotency = NewIdempotencyIndex(q.ID, q.db,
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.IdempotencyKey+config.Prefix),
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.IdempotencyKey+config.Suffix))
+
+	q.crons = NewCronTable(q.ID, q.db,
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.CronKey+config.Prefix),
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.CronKey+config.Suffix))
+
+	q.batches = NewBatchTable(q.ID, q.db,
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.BatchKey+config.Prefix),
+		[]byte(config.QueueKey+config.Prefix+q.ID+config.Prefix+config.BatchKey+config.Suffix))
+
+	q.breakers = NewBreakerTable(q.ID)
+}
+
+// initExecutors creates the executors of the current configuration.
+func (q *QueueManager) initExecutors() {
+	s, err := newExecutorSet(q.ID, q.Config)
+	if err != nil {
+		// Tasks fail until the queue is reconfigured.
+		log.Error(fmt.Sprintf("queue/%s - creating executors failed", q.ID), err)
+		s = &executorSet{config: q.Config, executors: map[string]Executor{}}
+	}
+	q.executors = s
+}
+
+// getConfig returns the current configuration together with the executors
+// built for it. A configuration is never changed once in use, Reconfigure
+// replaces it as a whole.
+func (q *QueueManager) getConfig() (*Config, *executorSet) {
+	q.configMu.RLock()
+	defer q.configMu.RUnlock()
+	return q.Config, q.executors
 }
 
-// initHTTPClient initializes the HTTP client.
-func (q *QueueManager) initHTTPClient() {
-	transport := stdhttp.Transport{
-		Dial: func(network, addr string) (net.Conn, error) {
-			return net.DialTimeout(network, addr, time.Duration(q.Config.TaskTimeout)*time.Second)
-		},
-		Proxy: stdhttp.ProxyFromEnvironment,
-		ResponseHeaderTimeout: time.Duration(q.Config.TaskTimeout) * time.Second,
+// ConfigCopy returns a copy of the queue's configuration that can be changed
+// without touching the running queue.
+func (q *QueueManager) ConfigCopy() *Config {
+	q.configMu.RLock()
+	c := *q.Config
+	q.configMu.RUnlock()
+
+	c.SigningSecrets = copyStrings(c.SigningSecrets)
+	c.Target.Schemes = copyStrings(c.Target.Schemes)
+	c.Target.Allow = copyStrings(c.Target.Allow)
+	c.Target.Deny = copyStrings(c.Target.Deny)
+	c.Commands = copyStrings(c.Commands)
+	if c.ExitNoRetry != nil {
+		c.ExitNoRetry = append([]int32{}, c.ExitNoRetry...)
 	}
-	q.httpClient = &stdhttp.Client{
-		Transport: &transport,
+	if c.ExecutorOptions != nil {
+		options := make(map[string]string, len(c.ExecutorOptions))
+		for k, v := range c.ExecutorOptions {
+			options[k] = v
+		}
+		c.ExecutorOptions = options
 	}
+	return &c
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+// Reconfigure switches a running queue to a new configuration. Tasks already
+// processing finish with the configuration they started with.
+func (q *QueueManager) Reconfigure(c *Config) error {
+	err := c.Validate()
+	if err != nil {
+		return err
+	}
+	executors, err := newExecutorSet(q.ID, c)
+	if err != nil {
+		return err
+	}
+
+	q.configMu.Lock()
+	old := q.executors
+	q.Config = c
+	q.executors = executors
+	q.waitQueue.setConfig(c)
+	q.scheduleQueue.setConfig(c)
+	q.deadQueue.setConfig(c)
+	q.configMu.Unlock()
+
+	// The wait queue might be idle because the queue was in pull mode.
+	q.waitQueue.Trigger()
+
+	old.Close()
+	log.Infof("queue/%s - reconfigured with %d worker(s)", q.ID, c.MaxConcurrent)
+	return nil
 }
 
 func (q *QueueManager) Start() {
 	defer q.mWaitGroup.Done()
 
 	log.Infof("queue/%s - starting with %d worker(s)", q.ID, q.Config.MaxConcurrent)
+	if q.IsPaused() {
+		q.signal(pause)
+	}
+	err := q.index.Rebuild(&q.waitQueue.queueLine, &q.scheduleQueue.queueLine)
+	if err != nil {
+		log.Error(fmt.Sprintf("queue/%s/index - rebuilding failed", q.ID), err)
+	}
+	q.recount()
+	q.recoverBatches()
 	go q.waitQueue.Run(func(task *Task) {
 		q.processTask(task)
 	})
 	go q.scheduleQueue.Run(func(task *Task) {
 		q.rescheduleTask(task)
 	})
+	go q.janitor()
+	go q.trimmer()
+	q.triggerTrim()
+	go q.cron()
+	go q.callbacks()
 	// Wait for all tasks currently processing to end.
 	q.qmWaitGroup.Wait()
 }
 
 func (q *QueueManager) Stop() {
-	if q.notifySignal == nil {
-		panic("notifySignal not created")
+	if q.lineSignals == nil {
+		panic("lineSignals not created")
+	}
+	close(q.quit)
+	q.signal(stop)
+}
+
+// Pause stops dispatching tasks from the queue. New tasks are still accepted
+// and tasks already processing run to completion.
+func (q *QueueManager) Pause() {
+	q.configMu.Lock()
+	q.Paused = true
+	q.configMu.Unlock()
+	q.signal(pause)
+}
+
+// Resume continues dispatching tasks from a paused queue, also ending a hold
+// asked for by a target. The hold ends whether the queue was paused or not.
+// It returns whether the queue was paused.
+func (q *QueueManager) Resume() bool {
+	q.configMu.Lock()
+	paused := q.Paused
+	q.Paused = false
+	q.configMu.Unlock()
+	q.waitQueue.Release()
+	q.signal(resume)
+	return paused
+}
+
+// IsPaused reports whether the queue is paused.
+func (q *QueueManager) IsPaused() bool {
+	q.configMu.RLock()
+	defer q.configMu.RUnlock()
+	return q.Paused
+}
+
+// signal sends a system signal to all running queue lines. Pause and resume
+// are only sent to lines with room for them, a line with signals pending
+// checks whether the queue is paused when it gets to them.
+func (q *QueueManager) signal(sig systemSignal) {
+	for _, c := range q.lineSignals {
+		if sig == stop {
+			c <- sig
+			continue
+		}
+		select {
+		case c <- sig:
+		default:
+		}
 	}
-	q.notifySignal <- stop
-	// Wait queue might be stuck on a "wait for new task signal".
-	q.waitQueue.Trigger()
 }
 
+// recount sets the task counts of the queue lines from what is stored.
+func (q *QueueManager) recount() {
+	q.stats.PendingBytes.Set(0)
+	q.waitQueue.recount()
+	pending := q.stats.PendingBytes.Get()
+	q.scheduleQueue.recount()
+	q.stats.PendingBytes.Add(pending)
+	q.deadQueue.recount()
+}
+
+// janitor periodically removes delivery attempts older than the configured
+// retention and idempotency keys that have left their window.
+func (q *QueueManager) janitor() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.quit:
+			return
+		case <-ticker.C:
+		}
+		c, _ := q.getConfig()
+		if c.HistoryRetention > 0 {
+			before := time.Now().Add(-time.Duration(c.HistoryRetention) * time.Second)
+			n, err := q.history.Prune(before)
+			if err != nil {
+				log.Error(fmt.Sprintf("queue/%s/history - pruning failed", q.ID), err)
+			} else if n > 0 {
+				log.Infof("queue/%s/history - pruned %d attempt(s)", q.ID, n)
+			}
+		}
+		n, err := q.idempotency.Prune(q.idempotencyCutoff())
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/idempotency - pruning failed", q.ID), err)
+		} else if n > 0 {
+			log.Infof("queue/%s/idempotency - pruned %d key(s)", q.ID, n)
+		}
+	}
+}
+
+// idempotencyCutoff returns the time before which idempotency keys are
+// forgotten.
+func (q *QueueManager) idempotencyCutoff() time.Time {
+	c, _ := q.getConfig()
+	window := c.IdempotencyWindow
+	if window <= 0 {
+		window = DefaultIdempotencyWindow
+	}
+	return time.Now().Add(-time.Duration(window) * time.Second)
+}
+
+// rescheduleTask moves a task that is due from the schedule queue to the wait
+// queue.
 func (q *QueueManager) rescheduleTask(task *Task) {
-	err := q.waitQueue.Add(task)
+	leased := q.scheduleQueue.isLeaseKey(task.Key)
+	if leased {
+		q.leaseMu.Lock()
+		defer q.leaseMu.Unlock()
+	}
+	q.taskMu.Lock()
+	defer q.taskMu.Unlock()
+
+	// The task might have been changed or cancelled since it was read, or
+	// acknowledged if leased.
+	k := task.Key
+	task, err := q.scheduleQueue.getKey(k)
 	if err != nil {
-		panic("Unable to add task to wait queue")
+		return
+	}
+	if leased {
+		log.Infof("queue/%s/task/%s - lease expired", q.ID, task.ID)
+	}
+	if task.Expired(time.Now()) {
+		q.expireTask(task)
+	} else {
+		err = q.waitQueue.Add(task)
+		if err != nil {
+			panic("Unable to add task to wait queue")
+		}
+	}
+	err = q.scheduleQueue.Delete(k)
+	if err != nil {
+		panic("Unable to delete task from schedule queue")
 	}
 }
 
@@ -161,31 +534,95 @@ func (q *QueueManager) processTask(task *Task) {
 
 	go func() {
 		defer func() {
-			<-q.waitQueue.notifyReady
+			q.waitQueue.ready.Release()
 			q.qmWaitGroup.Done()
 		}()
 
 		start := time.Now()
 
+		// The task might have been changed or cancelled since it was read.
 		k := task.Key
+		q.taskMu.Lock()
+		task, err := q.waitQueue.getKey(k)
+		if err != nil {
+			q.taskMu.Unlock()
+			return
+		}
+		q.processing[string(k)] = true
+		q.taskMu.Unlock()
+		defer func() {
+			q.taskMu.Lock()
+			delete(q.processing, string(k))
+			q.taskMu.Unlock()
+		}()
+
+		c, executors := q.getConfig()
 
-		err := task.Process(&q.ID, q.httpClient, q.Config, q.stats)
-		if err == ErrTaskMaxTries || err == ErrClientBadRequest {
-			// Do nothing.
-		} else if err != nil {
-			if task.Delay == 0 {
-				task.Delay = 1
+		if task.Expired(start) {
+			q.expireTask(task)
+			err := q.waitQueue.Delete(k)
+			if err != nil {
+				panic("Unable to delete task from wait queue")
 			}
-			task.Delay = task.Delay * 2
-			task.Tries = task.Tries + 1
+			return
+		}
 
-			err = q.scheduleQueue.Add(task, int64(task.Delay)+time.Now().Unix())
+		// Leave the task be while the target host is failing.
+		host := breakerHost(task.Target)
+		ok, probe, wait := q.breakers.allow(host, c)
+		if !ok {
+			q.deferTask(task, wait)
+			err := q.waitQueue.Delete(k)
 			if err != nil {
-				panic("Unable to add task to schedule queue")
+				panic("Unable to delete task from wait queue")
 			}
-		} else if err == nil {
+			return
+		}
+
+		// Hold the task to the limit of its host shared with other queues.
+		h := hostLimiterFor(task.Target)
+		if h != nil {
+			h.acquire()
+		}
+		outcome, err := task.Process(&q.ID, executors.get(task.Target), c, q.stats)
+		if h != nil {
+			h.release()
+		}
+		if task.attempt != nil {
+			q.history.Add(task.ID, task.attempt)
+			q.breakers.record(host, breakerFailure(outcome), c)
+		} else if probe {
+			q.breakers.cancelProbe(host)
+		}
+		switch outcome {
+		case OutcomeFail:
+			// Not retryable, keep it in the dead queue for inspection.
+			err = q.killTask(task, err)
+			if err != nil {
+				panic("Unable to add task to dead queue")
+			}
+		case OutcomeRetryAfter:
+			// Target told us when to come back, within reason.
+			wait := c.Retry.RetryAfter(task.retryAfter)
+			task.Tries = task.Tries + 1
+			task.Delay = int32((wait + time.Second - 1) / time.Second)
+			if c.RetryAfterHold {
+				q.waitQueue.Hold(time.Now().Add(wait))
+			}
+			q.retryTask(task)
+		case OutcomeRetry:
+			task.Tries = task.Tries + 1
+			task.Delay = c.Retry.Delay(task.Tries)
+			q.retryTask(task)
+		case OutcomeSuccess:
 			elapsed := time.Since(start)
 			q.statsProcessingQuantile.Insert(float64(elapsed / time.Millisecond))
+			if task.PipelineID != "" {
+				q.advancePipeline(task, task.response, task.responseType)
+			}
+			if task.BatchID != "" {
+				q.batchTaskDone(task, true)
+			}
 		}
 		err = q.waitQueue.Delete(k)
 		if err != nil {
@@ -194,14 +631,125 @@ func (q *QueueManager) processTask(task *Task) {
 	}()
 }
 
+// retryTask schedules the task for another try after its delay, unless the
+// task expires before then.
+func (q *QueueManager) retryTask(task *Task) {
+	scheduled := int64(task.Delay) + time.Now().Unix()
+	if task.ExpiresAt > 0 && scheduled >= task.ExpiresAt {
+		q.expireTask(task)
+		return
+	}
+	err := q.scheduleQueue.Add(task, scheduled)
+	if err != nil {
+		panic("Unable to add task to schedule queue")
+	}
+}
+
+// deferTask puts a task back in the schedule queue without counting a try,
+// unless the task expires before then.
+func (q *QueueManager) deferTask(task *Task, wait time.Duration) {
+	scheduled := time.Now().Add(wait + time.Second - 1).Unix()
+	if task.ExpiresAt > 0 && scheduled >= task.ExpiresAt {
+		q.expireTask(task)
+		return
+	}
+	err := q.scheduleQueue.Add(task, scheduled)
+	if err != nil {
+		panic("Unable to add task to schedule queue")
+	}
+	q.stats.TotalDeferred.Add(1)
+}
+
+// expireTask drops a task that is past its deadline. The caller removes it
+// from its queue line.
+func (q *QueueManager) expireTask(task *Task) {
+	log.Infof("queue/%s/task/%s - expired at %d, dropping", q.ID, task.ID, task.ExpiresAt)
+	q.stats.TotalExpired.Add(1)
+	if task.PipelineID != "" {
+		q.failPipeline(task, ErrPipelineStageExpired)
+	}
+	if task.BatchID != "" {
+		q.batchTaskDone(task, false)
+	}
+}
+
+// killTask moves a task that will not be retried to the dead queue. The
+// caller removes it from its queue line.
+func (q *QueueManager) killTask(task *Task, reason error) error {
+	err := q.deadQueue.Add(task, reason)
+	if err != nil {
+		return err
+	}
+	q.stats.TotalDead.Add(1)
+	if task.PipelineID != "" {
+		q.failPipeline(task, reason)
+	}
+	if task.BatchID != "" {
+		q.batchTaskDone(task, false)
+	}
+	return nil
+}
+
+// checkTarget checks a task against the global and the queue's target
+// policy.
+func (q *QueueManager) checkTarget(task *Task) error {
+	c, _ := q.getConfig()
+	err := checkTarget(task, &c.Target)
+	if err != nil {
+		return err
+	}
+	if isExecTarget(task.Target) {
+		if _, ok := c.command(task.Target); !ok {
+			return ErrCommandNotFound
+		}
+	}
+	return nil
+}
+
+// prepareTask checks a new task and readies it to be added, after the given
+// number of tasks of size bytes not yet counted in the queue.
+func (q *QueueManager) prepareTask(task *Task, staged, stagedSize int64) error {
+	err := task.Normalize()
+	if err != nil {
+		return err
+	}
+	err = q.checkTarget(task)
+	if err != nil {
+		return err
+	}
+	err = q.checkDepth(task, staged, stagedSize)
+	if err != nil {
+		return err
+	}
+	if task.ID == "" {
+		task.ID = <-q.newTaskID
+	} else if q.index.Get(task.ID) != nil {
+		// The id of a waiting or scheduled task is taken whether or not its
+		// idempotency key is still remembered.
+		return ErrTaskAlreadyExist
+	}
+	task.Tries = 0
+	task.Delay = 0
+	task.Status = 0
+	return nil
+}
+
 func (q *QueueManager) AddTask(target, payload string, scheduled int64) (*Task, error) {
-	start := time.Now()
 	task := &Task{
-		ID:      <-q.newTaskID,
 		Target:  target,
 		Payload: payload,
-		Tries:   0,
-		Delay:   0,
+	}
+	return q.Enqueue(task, scheduled)
+}
+
+// Enqueue adds a task built by the caller, i.e. with a custom method, headers
+// or content type. The task is assigned a new ID unless the caller supplied
+// one, and its retry counters are reset.
+func (q *QueueManager) Enqueue(task *Task, scheduled int64) (*Task, error) {
+	start := time.Now()
+	err := q.prepareTask(task, 0, 0)
+	if err != nil {
+		return nil, err
 	}
 	if scheduled == 0 {
 		// Normal task.
@@ -217,11 +765,38 @@ func (q *QueueManager) AddTask(target, payload string, scheduled int64) (*Task,
 		}
 	}
 	q.stats.TotalReceived.Add(1)
+	q.triggerTrim()
 	elapsed := time.Since(start)
 	q.statsAddQuantile.Insert(float64(elapsed / time.Millisecond))
 	return task, nil
 }
 
+// EnqueueIdempotent adds the task unless a task was already added with the
+// same idempotency key within the queue's idempotency window. In that case
+// the earlier task is returned and replayed is true.
+func (q *QueueManager) EnqueueIdempotent(task *Task, scheduled int64, idempotencyKey string) (res *Task, replayed bool, err error) {
+	if len(idempotencyKey) == 0 || len(idempotencyKey) > 255 {
+		return nil, false, ErrTaskInvalidKey
+	}
+
+	q.idempotencyMu.Lock()
+	defer q.idempotencyMu.Unlock()
+
+	if existing := q.idempotency.Get(idempotencyKey, q.idempotencyCutoff()); existing != nil {
+		log.Infof("queue/%s/task/%s - duplicate of idempotency key %q", q.ID, existing.ID, idempotencyKey)
+		return existing, true, nil
+	}
+	res, err = q.Enqueue(task, scheduled)
+	if err != nil {
+		return nil, false, err
+	}
+	err = q.idempotency.Add(idempotencyKey, res)
+	if err != nil {
+		return nil, false, err
+	}
+	return res, false, nil
+}
+
 func (q *QueueManager) GetTasks() (*[]Task, error) {
 	return q.waitQueue.GetAll()
 }
@@ -230,6 +805,357 @@ func (q *QueueManager) GetScheduledTasks() (*[]Task, error) {
 	return q.scheduleQueue.GetAll()
 }
 
+func (q *QueueManager) GetDeadTasks() (*[]DeadTask, error) {
+	return q.deadQueue.GetAll()
+}
+
+func (q *QueueManager) GetDeadTask(taskID string) (*DeadTask, error) {
+	return q.deadQueue.Get(taskID)
+}
+
+// RequeueDeadTask moves a dead task back to the wait queue with its retry
+// counters reset.
+func (q *QueueManager) RequeueDeadTask(taskID string) error {
+	dead, err := q.deadQueue.Get(taskID)
+	if err != nil {
+		return err
+	}
+	return q.requeueDeadTask(dead)
+}
+
+// RequeueDeadTasks moves all dead tasks back to the wait queue and returns
+// the number of tasks requeued.
+func (q *QueueManager) RequeueDeadTasks() (int, error) {
+	return q.eachDeadTask(q.requeueDeadTask)
+}
+
+func (q *QueueManager) requeueDeadTask(dead *DeadTask) error {
+	k := dead.Key
+	task := &dead.Task
+	task.Tries = 0
+	task.Delay = 0
+	task.Status = 0
+	// The batch member is pending again before the task leaves the dead
+	// queue.
+	if task.BatchID != "" {
+		q.batchTaskRequeued(task)
+	}
+	err := q.waitQueue.Add(task)
+	if err != nil {
+		if task.BatchID != "" {
+			q.batchTaskDone(task, false)
+		}
+		return err
+	}
+	if task.PipelineID != "" {
+		q.resumePipeline(task)
+	}
+	return q.deadQueue.Delete(k)
+}
+
+// DeleteDeadTask permanently removes a dead task.
+func (q *QueueManager) DeleteDeadTask(taskID string) error {
+	dead, err := q.deadQueue.Get(taskID)
+	if err != nil {
+		return err
+	}
+	return q.deadQueue.Delete(dead.Key)
+}
+
+// PurgeDeadTasks permanently removes all dead tasks and returns the number
+// of tasks removed.
+func (q *QueueManager) PurgeDeadTasks() (int, error) {
+	return q.eachDeadTask(func(dead *DeadTask) error {
+		return q.deadQueue.Delete(dead.Key)
+	})
+}
+
+// eachDeadTask applies fn to every dead task. The tasks are collected
+// before fn is called so fn is free to modify the dead queue.
+func (q *QueueManager) eachDeadTask(fn func(*DeadTask) error) (int, error) {
+	tasks := []*DeadTask{}
+	err := q.deadQueue.each(func(dead *DeadTask) bool {
+		tasks = append(tasks, dead)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, dead := range tasks {
+		err = fn(dead)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(tasks), nil
+}
+
+// TaskDetail is a task together with where it currently is and all recorded
+// delivery attempts.
+type TaskDetail struct {
+	ID       string    `json:"id"`
+	State    string    `json:"state"` // One of waiting, scheduled, leased, dead or done.
+	Task     *Task     `json:"task"`  // Nil once the task is done.
+	Reason   string    `json:"reason,omitempty"`
+	Attempts []Attempt `json:"attempts"`
+}
+
+const (
+	TaskStateWaiting   = "waiting"
+	TaskStateScheduled = "scheduled"
+	TaskStateLeased    = "leased"
+	TaskStateDead      = "dead"
+	TaskStateDone      = "done"
+)
+
+// GetTaskDetail looks the task up in all queue lines and returns it with its
+// delivery attempts. Tasks that have left all lines are reported as done as
+// long as attempts are recorded for them.
+func (q *QueueManager) GetTaskDetail(taskID string) (*TaskDetail, error) {
+	attempts, err := q.history.Get(taskID)
+	if err != nil {
+		return nil, err
+	}
+	detail := &TaskDetail{
+		ID:       taskID,
+		Attempts: attempts,
+	}
+
+	if task, err := q.waitQueue.Get(taskID); err == nil {
+		detail.State = TaskStateWaiting
+		detail.Task = task
+	} else if task, err := q.scheduleQueue.Get(taskID); err == nil {
+		detail.State = TaskStateScheduled
+		if q.scheduleQueue.isLeaseKey(task.Key) {
+			detail.State = TaskStateLeased
+		}
+		detail.Task = task
+	} else if dead, err := q.deadQueue.Get(taskID); err == nil {
+		detail.State = TaskStateDead
+		detail.Task = &dead.Task
+		detail.Reason = dead.Reason
+	} else if len(attempts) > 0 {
+		detail.State = TaskStateDone
+	} else {
+		return nil, ErrTaskNotFound
+	}
+	return detail, nil
+}
+
+// TaskUpdate holds the changes to a pending task. Nil fields are left as they
+// are.
+type TaskUpdate struct {
+	Target    *string
+	Payload   *string
+	Scheduled *int64 // Unix time to run the task at, a past time for now.
+}
+
+// pendingTask returns a waiting or scheduled task by id together with the
+// line it is in. Tasks being processed or leased can not be changed. The
+// caller holds leaseMu and taskMu.
+func (q *QueueManager) pendingTask(taskID string) (*Task, *queueLine, error) {
+	k := q.index.Get(taskID)
+	var line *queueLine
+	switch {
+	case k == nil:
+	case bytes.HasPrefix(k, q.waitQueue.prefix):
+		if q.processing[string(k)] {
+			return nil, nil, ErrTaskProcessing
+		}
+		line = &q.waitQueue.queueLine
+	case bytes.HasPrefix(k, q.scheduleQueue.prefix):
+		if q.scheduleQueue.isLeaseKey(k) {
+			return nil, nil, ErrTaskNotPending
+		}
+		line = &q.scheduleQueue.queueLine
+	}
+	if line == nil {
+		if _, err := q.deadQueue.Get(taskID); err == nil {
+			return nil, nil, ErrTaskNotPending
+		}
+		return nil, nil, ErrTaskNotFound
+	}
+	task, err := line.getKey(k)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, line, nil
+}
+
+// UpdateTask changes the target, payload or scheduled time of a waiting or
+// scheduled task. A task given a time in the future is moved to the schedule
+// queue, one given a past time to the wait queue.
+func (q *QueueManager) UpdateTask(taskID string, u *TaskUpdate) (*Task, error) {
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+	q.taskMu.Lock()
+	defer q.taskMu.Unlock()
+
+	task, line, err := q.pendingTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+	k := task.Key
+	if u.Target != nil {
+		task.Target = *u.Target
+	}
+	if u.Payload != nil {
+		task.Payload = *u.Payload
+	}
+	err = task.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	err = q.checkTarget(task)
+	if err != nil {
+		return nil, err
+	}
+
+	waiting := line == &q.waitQueue.queueLine
+	switch {
+	case u.Scheduled == nil:
+		err = line.update(task)
+	case *u.Scheduled > time.Now().Unix():
+		order := strconv.FormatInt(*u.Scheduled, 10)
+		if !waiting && bytes.Equal(q.scheduleQueue.key(task, order), k) {
+			err = line.update(task)
+			break
+		}
+		err = q.scheduleQueue.Add(task, *u.Scheduled)
+		if err == nil {
+			err = line.Delete(k)
+		}
+	case waiting:
+		err = line.update(task)
+	default:
+		err = q.waitQueue.Add(task)
+		if err == nil {
+			err = line.Delete(k)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("queue/%s/task/%s - changed", q.ID, task.ID)
+	return task, nil
+}
+
+// CancelTask removes a waiting or scheduled task. A cancelled task fails its
+// pipeline and counts as failed in its batch.
+func (q *QueueManager) CancelTask(taskID string) error {
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+	q.taskMu.Lock()
+	defer q.taskMu.Unlock()
+
+	task, line, err := q.pendingTask(taskID)
+	if err != nil {
+		return err
+	}
+	if task.BatchID != "" {
+		q.batchTaskDone(task, false)
+	}
+	err = line.Delete(task.Key)
+	if err != nil {
+		return err
+	}
+	log.Infof("queue/%s/task/%s - cancelled", q.ID, task.ID)
+	if task.PipelineID != "" {
+		q.failPipeline(task, ErrTaskCancelled)
+	}
+	return nil
+}
+
+// RunTask moves a scheduled task to the wait queue without waiting for its
+// time. Its retry counters are kept.
+func (q *QueueManager) RunTask(taskID string) error {
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+	q.taskMu.Lock()
+	defer q.taskMu.Unlock()
+
+	task, line, err := q.pendingTask(taskID)
+	if err != nil {
+		return err
+	}
+	if line != &q.scheduleQueue.queueLine {
+		return ErrTaskNotScheduled
+	}
+	return q.runScheduledTask(task)
+}
+
+// RunScheduledTasks moves all scheduled tasks but leased ones to the wait
+// queue and returns the number of tasks moved.
+func (q *QueueManager) RunScheduledTasks() (int, error) {
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+	q.taskMu.Lock()
+	defer q.taskMu.Unlock()
+
+	tasks := []*Task{}
+	iter := q.db.NewIterator(nil)
+	for iter.Seek(q.scheduleQueue.prefix); iter.Valid(); iter.Next() {
+		if bytes.Compare(iter.Key(), q.scheduleQueue.suffix) > 0 {
+			break
+		}
+		if q.scheduleQueue.isLeaseKey(iter.Key()) {
+			continue
+		}
+		k := append([]byte{}, iter.Key()...)
+		task, err := UnserializeTask(k, append([]byte{}, iter.Value()...))
+		if err != nil {
+			log.Error(fmt.Sprintf("queue/%s/%s - skipping task %q", q.ID, q.scheduleQueue.Type, k), err)
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	iter.Close()
+	for i, task := range tasks {
+		err := q.runScheduledTask(task)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(tasks), nil
+}
+
+func (q *QueueManager) runScheduledTask(task *Task) error {
+	k := task.Key
+	err := q.waitQueue.Add(task)
+	if err != nil {
+		return err
+	}
+	log.Infof("queue/%s/task/%s - run now", q.ID, task.ID)
+	return q.scheduleQueue.Delete(k)
+}
+
+// DeferTask moves a waiting task to the schedule queue to run at the given
+// unix time.
+func (q *QueueManager) DeferTask(taskID string, scheduled int64) error {
+	if scheduled <= time.Now().Unix() {
+		return ErrTaskInvalidSchedule
+	}
+	q.leaseMu.Lock()
+	defer q.leaseMu.Unlock()
+	q.taskMu.Lock()
+	defer q.taskMu.Unlock()
+
+	task, line, err := q.pendingTask(taskID)
+	if err != nil {
+		return err
+	}
+	if line != &q.waitQueue.queueLine {
+		return ErrTaskNotWaiting
+	}
+	k := task.Key
+	err = q.scheduleQueue.Add(task, scheduled)
+	if err != nil {
+		return err
+	}
+	log.Infof("queue/%s/task/%s - deferred to %d", q.ID, task.ID, scheduled)
+	return q.waitQueue.Delete(k)
+}
+
 func (q *QueueManager) Flush() error {
 	return nil
 }
@@ -237,6 +1163,24 @@ func (q *QueueManager) GetStats() *Stats {
 	return q.stats
 }
 
+// HeldFor returns how long dispatch from the queue is held because a target
+// answered with Retry-After.
+func (q *QueueManager) HeldFor() time.Duration {
+	return q.waitQueue.HeldFor()
+}
+
+// Breakers returns the circuit breakers of the target hosts that have failed
+// since their last success.
+func (q *QueueManager) Breakers() []BreakerState {
+	c, _ := q.getConfig()
+	return q.breakers.States(c)
+}
+
+// RateLimit returns the state of the queue's rate limiter.
+func (q *QueueManager) RateLimit() RateLimitState {
+	return q.waitQueue.limit.State()
+}
+
 func (q *QueueManager) GetStatsAddQuantile() *quantile.Stream {
 	return q.statsAddQuantile
 }
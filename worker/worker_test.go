@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigCopy(t *testing.T) {
+	c := &Config{
+		MaxConcurrent:   2,
+		SigningSecrets:  []string{"new", "old"},
+		Target:          TargetPolicy{Schemes: []string{"https"}, Allow: []string{".example.com"}, Deny: []string{"private"}},
+		ExecutorOptions: map[string]string{"region": "eu-west-1"},
+		Commands:        []string{"backup"},
+		ExitNoRetry:     []int32{64},
+	}
+	q := &QueueManager{Config: c}
+	got := q.ConfigCopy()
+	if !reflect.DeepEqual(got, c) {
+		t.Fatalf("Expected %+v, got %+v", c, got)
+	}
+
+	got.SigningSecrets[0] = "changed"
+	got.Target.Schemes[0] = "http"
+	got.Target.Allow[0] = "changed"
+	got.Target.Deny[0] = "changed"
+	got.ExecutorOptions["region"] = "changed"
+	got.Commands[0] = "changed"
+	got.ExitNoRetry[0] = 1
+	if c.SigningSecrets[0] != "new" || c.Target.Schemes[0] != "https" || c.Target.Allow[0] != ".example.com" ||
+		c.Target.Deny[0] != "private" || c.ExecutorOptions["region"] != "eu-west-1" || c.Commands[0] != "backup" ||
+		c.ExitNoRetry[0] != 64 {
+		t.Errorf("Expected the running config to be unchanged, got %+v", c)
+	}
+}
+
+func TestResumeReleasesHold(t *testing.T) {
+	tests := []struct {
+		paused bool
+	}{
+		{false},
+		{true},
+	}
+	for _, test := range tests {
+		q := &QueueManager{Config: &Config{MaxConcurrent: 1}, Paused: test.paused, waitQueue: &waitQueue{}}
+		q.waitQueue.Hold(time.Now().Add(time.Hour))
+		if q.waitQueue.HeldFor() <= 0 {
+			t.Fatalf("Expected the queue to be held")
+		}
+		if got := q.Resume(); got != test.paused {
+			t.Errorf("Expected Resume to report paused %v, got %v", test.paused, got)
+		}
+		if d := q.waitQueue.HeldFor(); d != 0 {
+			t.Errorf("Expected the hold to end on resume of a queue paused %v, held for %s", test.paused, d)
+		}
+		if q.IsPaused() {
+			t.Errorf("Expected the queue not to be paused after resume")
+		}
+	}
+}